@@ -1,11 +1,25 @@
 package config
 
-import "github.com/megatrZlp/go-apidocs/apidocs/render"
+import (
+	"html/template"
+	"io/fs"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/render"
+)
 
 // Config 用于自定义路由和预处理钩子。
 // - RouteDocs: 文档页面路由（默认 /docs）
 // - RouteMarkdown: Markdown 导出路由（默认 /docs.md）
+// - RouteDiff: 变更报告路由（默认 /docs/diff）
+// - RouteLint/Lint: 文档质量检查报告路由（默认 /docs/lint）与规则配置
 // - Preprocess: 在注册后允许外部对 Server 进行预处理（可选）
+// - Mock/RouteMock: 按规范生成模拟接口（可选）
+// - Lang/Locales: 界面语言与自定义语言目录
+// - Audience/Audiences: 受众裁剪（路由默认受众与允许按请求切换的受众）
+// - Customize/CustomizeFile: 按接口路径的定制规则，可从 YAML/JSON 文件加载并热更新
+// - GroupBy/GroupDelimiter/GroupPathDepth/GroupFunc: 导航与正文的分组策略
+// - OperationOrder/MethodOrder: 分组内接口与方法的排序
+// - AnchorOperationID: 接口锚点优先使用 operationId
 type Config struct {
 	// RouteDocs 文档页面路由（默认 /docs）
 	RouteDocs string
@@ -16,17 +30,118 @@ type Config struct {
 	// Domain+Port+Path 组合用于根据请求查询参数拼接远程 OpenAPI 源地址。
 	// 示例：当请求为 /docs?uuid=123&env=prod，且 Domain=api.example.com, Port=80, Path=/openapi
 	// 将拼接 http://api.example.com/openapi?uuid=123&env=prod 并拉取规范。
-	Domain      string
-	Port        int
-	Path        string
-	Customize   map[string]CustomizeReqAndRes
-	TemplateDir string
+	Domain string
+	Port   int
+	Path   string
+	// Customize 按接口的定制规则（Header 注入、请求/返回白名单）；键为选择器：[方法 ]精确路径、路径通配（* 段内、** 跨段）、
+	// re:<正则>、tag:<标签名> 或 operationId:<id>，多条命中时按优先级合并（见 README）
+	Customize map[string]CustomizeReqAndRes
+	// CustomizeFile 定制规则文件（YAML/JSON，格式见 LoadCustomizeFile），与 Customize 合并（同一路径下文件优先）；
+	// 文件变更后自动重新加载，校验失败时记录错误并保留上一次生效的规则
+	CustomizeFile string
+	TemplateDir   string
+	// TemplateFS 模板来源（embed.FS、os.DirFS、zip.Reader 等），靠前者优先，其后依次为 TemplateDir 与内置模板
+	TemplateFS []fs.FS
+	// DevMode 为 true 时每次请求重新读取并解析模板，便于调试；默认解析一次后缓存
+	DevMode bool
+	// HideDeprecated 为 true 时不输出已废弃的接口、参数与字段；请求中的 ?deprecated=hide|show 优先
+	HideDeprecated bool
+	// Audience 路由默认受众：按 x-audience/x-internal 裁剪接口、标签、参数与字段（缺省不裁剪）
+	Audience string
+	// Audiences 允许通过 ?audience= 切换的受众；未列出的值被忽略（避免外部调用方请求 internal 视图）
+	Audiences []string
+	// Lang 界面语言（内置 zh-CN、en，缺省 zh-CN）；请求中的 ?lang= 与 Accept-Language 优先
+	Lang string
+	// Locales 自定义语言目录：语言代码 → 消息键 → 文本，可新增语言或覆盖内置文案
+	Locales map[string]map[string]string
+	// TemplateFuncs 自定义模板函数，合并到内置函数库（anchor/slugify/markdown 等）中
+	TemplateFuncs template.FuncMap
+	// RouteDiff 变更报告路由（默认 /docs/diff），比较 base 与 target 两个数据源
+	RouteDiff string
+	// RouteLint 文档质量检查报告路由（默认 /docs/lint）
+	RouteLint string
+	// Lint 文档质量检查规则配置（禁用规则、覆盖级别）
+	Lint LintConfig
+	// Mock 为 true 时，在 RouteMock 前缀下绑定规范中的所有路径与方法并返回示例响应
+	Mock bool
+	// RouteMock 模拟服务路由前缀（默认 /mock），例如 GET /mock/users/1 对应规范中的 GET /users/{id}
+	RouteMock string
+	// GroupBy 分组策略：tag（缺省，按 tags[0]）、tags（全部标签，接口在每个分组下各列一次）、path（路径前缀段）、x-group（扩展字段）
+	GroupBy string
+	// GroupDelimiter 标签、x-group 与 Customize.Group 中分组层级的分隔符（缺省 "/"，如 "."、"::"）
+	GroupDelimiter string
+	// GroupPathDepth 按路径分组时使用的前缀段数（缺省 2）
+	GroupPathDepth int
+	// GroupFunc 自定义分组函数，设置后优先于 GroupBy
+	GroupFunc render.GroupFunc
+	// OperationOrder 分组内接口的排序：spec（缺省，paths 原始顺序）、path（路径字母序）、summary（标题字母序）；x-order 始终优先
+	OperationOrder string
+	// MethodOrder 同一路径下方法的排序：alpha（缺省，字母序）、canonical（GET、POST、PUT、PATCH、DELETE…）
+	MethodOrder string
+	// AnchorOperationID 为 true 时接口锚点优先使用 operationId（缺省为方法+路径）；旧锚点打开时自动跳转到新锚点
+	AnchorOperationID bool
 }
 
+// CustomizeReqAndRes 按接口路径的定制规则，用于渲染时注入 Header、以及对请求/返回参数与示例进行白名单过滤。
+// 过滤规则说明：
+// - Request/Response 未设置（nil）时，不做过滤，完整展示；设置为非空切片时，按白名单过滤
+// - Response 过滤仅作用于 data 内部的字段，顶层 code/message/data 永远保留
+// - 白名单支持两类写法：
+//  1. 叶子名：例如 "completeCode"，会保留 data 下所有名为 completeCode 的最底层基础类型字段
+//  2. 完整路径：支持数组写法（.items[] 或 [] 等价），例如
+//     "data.departments.items[].address"、"data.professions.items[].code"
+//
+// - Request 采用与 Response 相同的白名单规则（按 data 下叶子或完整路径过滤）；未设置则不过滤
+// - Header 注入：Headers 的值格式为 "type#required#desc" 或 "type#desc"，其中 required/optional（或 必选/可选）会被解析为“是/否”，type 与 desc 分别写入类型与说明
+// - Params: 结构化注入 header/query/path/cookie 参数（name、in、type、required、description、example、enum），与 Headers 同名时以 Params 为准；规范中已声明的同位置同名参数不会被覆盖
+// - Exclude: 字段黑名单（请求体与返回体共用），写法与白名单相同；命中的字段连同子字段从表格与示例中移除，任意层级生效
+// - Rename: 字段重命名，键为字段名或完整路径，值为新的字段名（仅名称，不含路径）
+// - Fields: 按字段名或完整路径覆盖说明、类型与示例值
+// - 黑名单、重命名与覆盖均按规范中的原始字段名匹配，并在白名单过滤之后应用
+// - Hide: 从导航、正文与 Markdown 导出中隐藏接口
+// - Summary: 覆盖接口标题；Group: 替换 tags[0] 以移动到其他分组，写法同 tags[0]（“主/次”）
+// - Note/Warning: 在接口标题下附加说明或警告提示（支持 Markdown）
+// - ContentType: 覆盖“请求方式”中展示的 Content-Type
 type CustomizeReqAndRes struct {
-	Headers  map[string]string
-	Request  []string
-	Response []string
+	Headers     map[string]string
+	Params      []ParamSpec
+	Request     []string
+	Response    []string
+	Exclude     []string
+	Rename      map[string]string
+	Fields      map[string]FieldOverride
+	Hide        bool
+	Summary     string
+	Group       string
+	Note        string
+	Warning     string
+	ContentType string
+}
+
+// FieldOverride 单个字段的展示覆盖：非空的 Desc/Type 替换字段表中的说明与类型，非 nil 的 Example 替换示例中的取值。
+type FieldOverride struct {
+	Desc    string
+	Type    string
+	Example interface{}
+}
+
+// ParamSpec 注入的参数：In 为 header/query/path/cookie（不区分大小写，缺省 header，其他取值的参数不注入），path 参数始终必选；Example 展示在约束列。
+type ParamSpec struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+	Example     interface{}
+	Enum        []string
+}
+
+// LintConfig 文档质量检查配置。
+// - Disabled: 禁用的规则名（见 render.LintRules）
+// - Severity: 按规则名覆盖级别（error/warning/info）
+type LintConfig struct {
+	Disabled []string
+	Severity map[string]string
 }
 
 // WithDefaults 返回带默认值的配置副本。
@@ -38,38 +153,72 @@ func (c *Config) WithDefaults() Config {
 	if d.RouteMarkdown == "" {
 		d.RouteMarkdown = "/docs.md"
 	}
-	d.Customize = d.customize()
-	return d
-}
-
-// customize 返回“按接口路径”配置的定制规则集合，用于渲染时注入 Header、以及对请求/返回参数与示例进行白名单过滤。
-// 过滤规则说明：
-// - Request/Response 未设置（nil）时，不做过滤，完整展示；设置为非空切片时，按白名单过滤
-// - Response 过滤仅作用于 data 内部的字段，顶层 code/message/data 永远保留
-// - 白名单支持两类写法：
-//  1. 叶子名：例如 "completeCode"，会保留 data 下所有名为 completeCode 的最底层基础类型字段
-//  2. 完整路径：支持数组写法（.items[] 或 [] 等价），例如
-//     "data.departments.items[].address"、"data.professions.items[].code"
-//
-// - Request 采用与 Response 相同的白名单规则（按 data 下叶子或完整路径过滤）；未设置则不过滤
-// - Header 注入：Headers 的值格式为 "type#required#desc" 或 "type#desc"，其中 required/optional（或 必选/可选）会被解析为“是/否”，type 与 desc 分别写入类型与说明
-func (c *Config) customize() map[string]CustomizeReqAndRes {
-	customize := make(map[string]CustomizeReqAndRes)
-	customize["/v1/record/record/*"] = CustomizeReqAndRes{
-		Headers: map[string]string{
-			"accessToken": "string#required#登录获取的accessToken",
-		},
-		Request:  nil,
-		Response: nil,
+	if d.RouteDiff == "" {
+		d.RouteDiff = "/docs/diff"
+	}
+	if d.RouteLint == "" {
+		d.RouteLint = "/docs/lint"
 	}
-	return customize
+	if d.RouteMock == "" {
+		d.RouteMock = "/mock"
+	}
+	d.Customize = MergeCustomize(nil, d.Customize)
+	return d
 }
 
 // ToRenderConfig 映射到渲染配置结构
 func (c Config) ToRenderConfig() render.RenderConfig {
 	m := make(map[string]render.CustomizeReqAndRes, len(c.Customize))
 	for k, v := range c.Customize {
-		m[k] = render.CustomizeReqAndRes{Headers: v.Headers, Request: v.Request, Response: v.Response}
+		fields := make(map[string]render.FieldOverride, len(v.Fields))
+		for name, o := range v.Fields {
+			fields[name] = render.FieldOverride{Desc: o.Desc, Type: o.Type, Example: o.Example}
+		}
+		var params []render.ParamSpec
+		for _, p := range v.Params {
+			params = append(params, render.ParamSpec(p))
+		}
+		m[k] = render.CustomizeReqAndRes{
+			Headers:     v.Headers,
+			Params:      params,
+			Request:     v.Request,
+			Response:    v.Response,
+			Exclude:     v.Exclude,
+			Rename:      v.Rename,
+			Fields:      fields,
+			Hide:        v.Hide,
+			Summary:     v.Summary,
+			Group:       v.Group,
+			Note:        v.Note,
+			Warning:     v.Warning,
+			ContentType: v.ContentType,
+		}
 	}
-	return render.RenderConfig{RouteMarkdown: c.RouteMarkdown, Customize: m, TemplateDir: c.TemplateDir}
+	locales := make(map[string]render.Locale, len(c.Locales))
+	for k, v := range c.Locales {
+		locales[k] = render.Locale(v)
+	}
+	return render.RenderConfig{
+		RouteMarkdown:     c.RouteMarkdown,
+		Customize:         m,
+		TemplateFS:        c.TemplateFS,
+		TemplateDir:       c.TemplateDir,
+		DevMode:           c.DevMode,
+		HideDeprecated:    c.HideDeprecated,
+		Funcs:             c.TemplateFuncs,
+		Lang:              c.Lang,
+		Locales:           locales,
+		GroupBy:           c.GroupBy,
+		GroupDelimiter:    c.GroupDelimiter,
+		GroupPathDepth:    c.GroupPathDepth,
+		GroupFunc:         c.GroupFunc,
+		OperationOrder:    c.OperationOrder,
+		MethodOrder:       c.MethodOrder,
+		AnchorOperationID: c.AnchorOperationID,
+	}
+}
+
+// ToLintConfig 映射到文档质量检查配置
+func (c Config) ToLintConfig() render.LintConfig {
+	return render.LintConfig{Disabled: c.Lint.Disabled, Severity: c.Lint.Severity}
 }
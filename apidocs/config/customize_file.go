@@ -0,0 +1,473 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/render"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// CustomizeError 定制规则文件中的一处错误，指明文件、规则（接口路径）与字段。
+type CustomizeError struct {
+	File    string
+	Rule    string
+	Field   string
+	Message string
+}
+
+func (e *CustomizeError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.File)
+	if e.Rule != "" {
+		b.WriteString(": rule " + fmt.Sprintf("%q", e.Rule))
+	}
+	if e.Field != "" {
+		b.WriteString(": " + e.Field)
+	}
+	b.WriteString(": " + e.Message)
+	return b.String()
+}
+
+// LoadCustomizeFile 从 YAML 或 JSON 文件读取按接口路径的定制规则（按扩展名 .yaml/.yml/.json 识别格式，其余自动识别）。
+// 文件结构为“规则键（接口路径或选择器，见 render.ValidateSelector）→ 规则”的映射，可直接位于顶层，也可置于 customize 键下：
+//
+//	customize:
+//	  /v1/record/record/**:
+//	    headers:
+//	      accessToken: string#required#登录获取的accessToken
+//	    request: [recordId]
+//	    response: ["data.list[].id"]
+//	    exclude: [internalFlag, traceId]
+//	    rename: {uid: userId}
+//	    fields:
+//	      data.list[].status: {desc: 状态：1 正常 2 停用, type: integer, example: 1}
+//	  /v1/debug/**:
+//	    hide: true
+//	  POST /v1/upload:
+//	    summary: 上传文件
+//	    group: 文件/上传
+//	    warning: 单个文件不超过 **10MB**
+//	    contentType: multipart/form-data
+//
+// 规则键（headers、request、contentType 等）及 fields 下的 desc/type/example 不区分大小写。校验失败时返回 errors.Join 合并的 *CustomizeError，
+// 每项指明出错的规则与字段。
+func LoadCustomizeFile(path string) (map[string]CustomizeReqAndRes, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &CustomizeError{File: path, Message: err.Error()}
+	}
+	var j *gjson.Json
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		j, err = gjson.LoadContentType(gjson.ContentTypeYaml, content)
+	case ".json":
+		j, err = gjson.LoadContentType(gjson.ContentTypeJSON, content)
+	default:
+		j, err = gjson.LoadContent(content)
+	}
+	if err != nil {
+		return nil, &CustomizeError{File: path, Message: "parse: " + err.Error()}
+	}
+	return parseCustomize(path, j.Map())
+}
+
+// parseCustomize 将解码后的文件内容转换为定制规则，并收集全部校验错误。
+func parseCustomize(file string, root map[string]interface{}) (map[string]CustomizeReqAndRes, error) {
+	if inner, ok := root["customize"]; ok {
+		m, ok := inner.(map[string]interface{})
+		if !ok && inner != nil {
+			return nil, &CustomizeError{File: file, Field: "customize", Message: "must be a map of path to rule"}
+		}
+		root = m
+	}
+	keys := make([]string, 0, len(root))
+	for k := range root {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res := make(map[string]CustomizeReqAndRes, len(root))
+	var errs []error
+	fail := func(rule, field, msg string) {
+		errs = append(errs, &CustomizeError{File: file, Rule: rule, Field: field, Message: msg})
+	}
+	for _, path := range keys {
+		if err := render.ValidateSelector(path); err != nil {
+			fail(path, "", err.Error())
+			continue
+		}
+		body, ok := root[path].(map[string]interface{})
+		if !ok {
+			if root[path] != nil {
+				fail(path, "", "rule must be a map with headers/request/response")
+			}
+			continue
+		}
+		var rule CustomizeReqAndRes
+		for k, v := range body {
+			switch strings.ToLower(k) {
+			case "headers":
+				rule.Headers = parseCustomizeHeaders(v, func(field, msg string) { fail(path, field, msg) })
+			case "params":
+				rule.Params = parseCustomizeParams(v, func(field, msg string) { fail(path, field, msg) })
+			case "request":
+				rule.Request = parseCustomizeList(v, func(msg string) { fail(path, k, msg) })
+			case "response":
+				rule.Response = parseCustomizeList(v, func(msg string) { fail(path, k, msg) })
+			case "exclude":
+				rule.Exclude = parseCustomizeList(v, func(msg string) { fail(path, k, msg) })
+			case "rename":
+				rule.Rename = parseCustomizeRename(v, func(field, msg string) { fail(path, field, msg) })
+			case "fields":
+				rule.Fields = parseCustomizeFields(v, func(field, msg string) { fail(path, field, msg) })
+			case "hide":
+				b, ok := v.(bool)
+				if !ok {
+					fail(path, k, "must be a boolean")
+				}
+				rule.Hide = b
+			case "summary", "group", "note", "warning", "contenttype":
+				str, ok := v.(string)
+				if !ok {
+					fail(path, k, "must be a string")
+				}
+				switch strings.ToLower(k) {
+				case "summary":
+					rule.Summary = str
+				case "group":
+					rule.Group = str
+				case "note":
+					rule.Note = str
+				case "warning":
+					rule.Warning = str
+				default:
+					rule.ContentType = str
+				}
+			default:
+				fail(path, k, "unknown key (expected headers, params, request, response, exclude, rename, fields, hide, summary, group, note, warning or contentType)")
+			}
+		}
+		res[path] = rule
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return res, nil
+}
+
+// parseCustomizeHeaders 解析 headers：Header 名 → "type#required#desc" 或 "type#desc"。
+func parseCustomizeHeaders(v interface{}, fail func(field, msg string)) map[string]string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		if v != nil {
+			fail("headers", "must be a map of header name to spec")
+		}
+		return nil
+	}
+	res := make(map[string]string, len(m))
+	for name, spec := range m {
+		field := "headers." + name
+		s, ok := spec.(string)
+		if !ok {
+			fail(field, "spec must be a string like type#required#desc")
+			continue
+		}
+		if err := validateHeaderSpec(s); err != "" {
+			fail(field, err)
+			continue
+		}
+		res[name] = s
+	}
+	return res
+}
+
+// validateHeaderSpec 校验 Header 规格，返回错误说明；合法时返回空串。
+func validateHeaderSpec(spec string) string {
+	parts := strings.Split(spec, "#")
+	if strings.TrimSpace(parts[0]) == "" {
+		return fmt.Sprintf("missing type in %q", spec)
+	}
+	if len(parts) > 3 {
+		return fmt.Sprintf("too many # segments in %q (expected type#required#desc or type#desc)", spec)
+	}
+	if len(parts) == 3 {
+		switch strings.ToLower(strings.TrimSpace(parts[1])) {
+		case "required", "optional", "必选", "可选":
+		default:
+			return fmt.Sprintf("invalid required flag %q (expected required, optional, 必选 or 可选)", parts[1])
+		}
+	}
+	return ""
+}
+
+// parseCustomizeParams 解析 params：参数列表，每项为 {name, in, type, required, description, example, enum}。
+func parseCustomizeParams(v interface{}, fail func(field, msg string)) []ParamSpec {
+	arr, ok := v.([]interface{})
+	if !ok {
+		if v != nil {
+			fail("params", "must be a list of parameters")
+		}
+		return nil
+	}
+	res := make([]ParamSpec, 0, len(arr))
+	for i, it := range arr {
+		field := fmt.Sprintf("params[%d]", i)
+		m, ok := it.(map[string]interface{})
+		if !ok {
+			fail(field, "parameter must be a map with name and in")
+			continue
+		}
+		var p ParamSpec
+		valid := true
+		for k, val := range m {
+			switch strings.ToLower(k) {
+			case "name":
+				p.Name, ok = val.(string)
+			case "in":
+				p.In, ok = val.(string)
+				if ok && !render.IsParamIn(p.In) {
+					fail(field+"."+k, fmt.Sprintf("invalid location %q (expected header, query, path or cookie)", p.In))
+					valid = false
+				}
+			case "type":
+				p.Type, ok = val.(string)
+			case "description", "desc":
+				p.Description, ok = val.(string)
+			case "required":
+				if p.Required, ok = val.(bool); !ok {
+					fail(field+"."+k, "must be a boolean")
+					valid = false
+					continue
+				}
+			case "example":
+				p.Example, ok = val, true
+			case "enum":
+				var list []interface{}
+				if list, ok = val.([]interface{}); !ok {
+					fail(field+"."+k, "must be a list of values")
+					valid = false
+					continue
+				}
+				for _, e := range list {
+					p.Enum = append(p.Enum, fmt.Sprint(e))
+				}
+			default:
+				fail(field+"."+k, "unknown key (expected name, in, type, required, description, example or enum)")
+				valid = false
+				continue
+			}
+			if !ok {
+				fail(field+"."+k, "must be a string")
+				valid = false
+			}
+		}
+		if strings.TrimSpace(p.Name) == "" {
+			fail(field+".name", "name is required")
+			valid = false
+		}
+		if valid {
+			p.In = strings.ToLower(p.In)
+			if p.In == "" {
+				p.In = "header"
+			}
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// parseCustomizeList 解析 request/response 白名单：字符串数组，元素不可为空。
+func parseCustomizeList(v interface{}, fail func(msg string)) []string {
+	if v == nil {
+		return nil
+	}
+	arr, ok := v.([]interface{})
+	if !ok {
+		fail("must be a list of field names or paths")
+		return nil
+	}
+	res := make([]string, 0, len(arr))
+	for i, it := range arr {
+		s, ok := it.(string)
+		if !ok || strings.TrimSpace(s) == "" {
+			fail(fmt.Sprintf("item %d must be a non-empty string", i))
+			continue
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// parseCustomizeRename 解析 rename：字段名或完整路径 → 新字段名（不可含 . 或 []）。
+func parseCustomizeRename(v interface{}, fail func(field, msg string)) map[string]string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		if v != nil {
+			fail("rename", "must be a map of field to new name")
+		}
+		return nil
+	}
+	res := make(map[string]string, len(m))
+	for from, to := range m {
+		field := "rename." + from
+		s, ok := to.(string)
+		switch {
+		case !ok || strings.TrimSpace(s) == "":
+			fail(field, "new name must be a non-empty string")
+		case strings.ContainsAny(s, ".[]"):
+			fail(field, fmt.Sprintf("new name %q must be a plain field name without . or []", s))
+		default:
+			res[from] = s
+		}
+	}
+	return res
+}
+
+// parseCustomizeFields 解析 fields：字段名或完整路径 → {desc, type, example}。
+func parseCustomizeFields(v interface{}, fail func(field, msg string)) map[string]FieldOverride {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		if v != nil {
+			fail("fields", "must be a map of field to override")
+		}
+		return nil
+	}
+	res := make(map[string]FieldOverride, len(m))
+	for name, body := range m {
+		field := "fields." + name
+		bm, ok := body.(map[string]interface{})
+		if !ok {
+			fail(field, "override must be a map with desc, type or example")
+			continue
+		}
+		var o FieldOverride
+		valid := true
+		for k, val := range bm {
+			switch strings.ToLower(k) {
+			case "desc", "description":
+				o.Desc, ok = val.(string)
+			case "type":
+				o.Type, ok = val.(string)
+			case "example":
+				o.Example, ok = val, true
+			default:
+				fail(field+"."+k, "unknown key (expected desc, type or example)")
+				valid = false
+				continue
+			}
+			if !ok {
+				fail(field+"."+k, "must be a string")
+				valid = false
+			}
+		}
+		if valid {
+			res[name] = o
+		}
+	}
+	return res
+}
+
+// MergeCustomize 合并两组定制规则，返回新集合（不修改入参）：
+// - 仅一方声明的路径原样保留；
+// - 同一路径下 overlay 的 Headers、Params（按位置+名称）、Rename、Fields 逐项覆盖 base，overlay 的 Request/Response/Exclude 非 nil 时替换 base；
+// - Summary/Group/Note/Warning/ContentType 以 overlay 的非空值为准，任一方设置 Hide 即隐藏。
+func MergeCustomize(base, overlay map[string]CustomizeReqAndRes) map[string]CustomizeReqAndRes {
+	res := make(map[string]CustomizeReqAndRes, len(base)+len(overlay))
+	for k, v := range base {
+		res[k] = v
+	}
+	for k, o := range overlay {
+		b, ok := res[k]
+		if !ok {
+			res[k] = o
+			continue
+		}
+		if len(o.Headers) > 0 {
+			h := make(map[string]string, len(b.Headers)+len(o.Headers))
+			for name, spec := range b.Headers {
+				h[name] = spec
+			}
+			for name, spec := range o.Headers {
+				h[name] = spec
+			}
+			b.Headers = h
+		}
+		if len(o.Params) > 0 {
+			b.Params = mergeParams(b.Params, o.Params)
+		}
+		if len(o.Rename) > 0 {
+			r := make(map[string]string, len(b.Rename)+len(o.Rename))
+			for name, to := range b.Rename {
+				r[name] = to
+			}
+			for name, to := range o.Rename {
+				r[name] = to
+			}
+			b.Rename = r
+		}
+		if len(o.Fields) > 0 {
+			f := make(map[string]FieldOverride, len(b.Fields)+len(o.Fields))
+			for name, ov := range b.Fields {
+				f[name] = ov
+			}
+			for name, ov := range o.Fields {
+				f[name] = ov
+			}
+			b.Fields = f
+		}
+		b.Hide = b.Hide || o.Hide
+		overrideString(&b.Summary, o.Summary)
+		overrideString(&b.Group, o.Group)
+		overrideString(&b.Note, o.Note)
+		overrideString(&b.Warning, o.Warning)
+		overrideString(&b.ContentType, o.ContentType)
+		if o.Exclude != nil {
+			b.Exclude = o.Exclude
+		}
+		if o.Request != nil {
+			b.Request = o.Request
+		}
+		if o.Response != nil {
+			b.Response = o.Response
+		}
+		res[k] = b
+	}
+	return res
+}
+
+// overrideString src 非空时覆盖 dst。
+func overrideString(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
+
+// mergeParams 合并注入参数：overlay 中同位置同名者替换 base 的对应项，其余追加到末尾。
+func mergeParams(base, overlay []ParamSpec) []ParamSpec {
+	key := func(p ParamSpec) string {
+		in := strings.ToLower(p.In)
+		if in == "" || in == "header" {
+			return "header:" + strings.ToLower(p.Name)
+		}
+		return in + ":" + p.Name
+	}
+	res := append([]ParamSpec(nil), base...)
+	for _, o := range overlay {
+		replaced := false
+		for i := range res {
+			if key(res[i]) == key(o) {
+				res[i], replaced = o, true
+				break
+			}
+		}
+		if !replaced {
+			res = append(res, o)
+		}
+	}
+	return res
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeCustomizeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCustomizeFile(t *testing.T) {
+	want := map[string]CustomizeReqAndRes{
+		"/v1/record/**": {
+			Headers:  map[string]string{"accessToken": "string#required#登录获取的accessToken"},
+			Request:  []string{"recordId"},
+			Response: []string{"data.list[].id"},
+			Exclude:  []string{"traceId"},
+			Rename:   map[string]string{"uid": "userId"},
+			Fields:   map[string]FieldOverride{"data.list[].status": {Desc: "状态", Type: "integer", Example: 1}},
+		},
+		"/v1/debug/**":    {Hide: true},
+		"POST /v1/upload": {Summary: "上传文件", Group: "文件/上传", Warning: "单个文件不超过 **10MB**", ContentType: "multipart/form-data"},
+	}
+	files := map[string]string{
+		// customize 键下；规则键与 fields 下的键不区分大小写
+		"rules.yaml": `customize:
+  /v1/record/**:
+    Headers:
+      accessToken: string#required#登录获取的accessToken
+    request: [recordId]
+    response: ["data.list[].id"]
+    exclude: [traceId]
+    rename: {uid: userId}
+    fields:
+      data.list[].status: {DESC: 状态, type: integer, example: 1}
+  /v1/debug/**:
+    hide: true
+  POST /v1/upload:
+    summary: 上传文件
+    group: 文件/上传
+    warning: 单个文件不超过 **10MB**
+    contentType: multipart/form-data
+`,
+		// 规则直接位于顶层
+		"rules.json": `{
+  "/v1/record/**": {"headers": {"accessToken": "string#required#登录获取的accessToken"}, "request": ["recordId"], "response": ["data.list[].id"],
+    "exclude": ["traceId"], "rename": {"uid": "userId"}, "fields": {"data.list[].status": {"desc": "状态", "type": "integer", "example": 1}}},
+  "/v1/debug/**": {"hide": true},
+  "POST /v1/upload": {"summary": "上传文件", "group": "文件/上传", "warning": "单个文件不超过 **10MB**", "contentType": "multipart/form-data"}
+}`,
+	}
+	for name, content := range files {
+		got, err := LoadCustomizeFile(writeCustomizeFile(t, name, content))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		// 按打印形式比较：解码得到的空集合与 nil、YAML 与 JSON 的数字类型不做区分
+		if fmt.Sprintf("%+v", got) != fmt.Sprintf("%+v", want) {
+			t.Errorf("%s: rules = %+v, want %+v", name, got, want)
+		}
+	}
+
+	// 无法识别的扩展名按内容自动识别
+	got, err := LoadCustomizeFile(writeCustomizeFile(t, "rules.conf", `{"/a": {"hide": true}}`))
+	if err != nil || !got["/a"].Hide {
+		t.Errorf("auto-detected rules = %+v, %v", got, err)
+	}
+	// 空的 customize 键得到空规则
+	if got, err = LoadCustomizeFile(writeCustomizeFile(t, "empty.yaml", "customize:\n")); err != nil || len(got) != 0 {
+		t.Errorf("empty customize = %+v, %v", got, err)
+	}
+}
+
+func TestLoadCustomizeFileErrors(t *testing.T) {
+	if _, err := LoadCustomizeFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("missing file: expected an error")
+	}
+	if _, err := LoadCustomizeFile(writeCustomizeFile(t, "bad.json", `{"/a": `)); err == nil || !strings.Contains(err.Error(), "parse: ") {
+		t.Errorf("invalid json: err = %v", err)
+	}
+
+	file := writeCustomizeFile(t, "rules.yaml", `customize:
+  /a:
+    headers: {token: "string#maybe#x", id: "#desc"}
+    request: [id, ""]
+    rename: {uid: user.id}
+    fields: {name: {label: x}}
+    hide: "yes"
+    summary: 1
+    colour: red
+  "re:(":
+    hide: true
+  /b: [1]
+  /c:
+    hide: true
+`)
+	_, err := LoadCustomizeFile(file)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	want := []CustomizeError{
+		{Rule: "/a", Field: "headers.token", Message: `invalid required flag "maybe"`},
+		{Rule: "/a", Field: "headers.id", Message: `missing type in "#desc"`},
+		{Rule: "/a", Field: "request", Message: "item 1 must be a non-empty string"},
+		{Rule: "/a", Field: "rename.uid", Message: "must be a plain field name"},
+		{Rule: "/a", Field: "fields.name.label", Message: "unknown key"},
+		{Rule: "/a", Field: "hide", Message: "must be a boolean"},
+		{Rule: "/a", Field: "summary", Message: "must be a string"},
+		{Rule: "/a", Field: "colour", Message: "unknown key"},
+		{Rule: "/b", Message: "rule must be a map"},
+		{Rule: "re:(", Message: "invalid regex"},
+	}
+	var errs []*CustomizeError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var ce *CustomizeError
+		if !errors.As(e, &ce) {
+			t.Fatalf("error %v is not a *CustomizeError", e)
+		}
+		errs = append(errs, ce)
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d:\n%v", len(errs), len(want), err)
+	}
+	for _, w := range want {
+		found := false
+		for _, e := range errs {
+			if e.File == file && e.Rule == w.Rule && e.Field == w.Field && strings.Contains(e.Message, w.Message) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing error %+v in:\n%v", w, err)
+		}
+	}
+	if !strings.HasPrefix(errs[0].Error(), file+`: rule "/a": `) {
+		t.Errorf("error text = %q", errs[0].Error())
+	}
+}
+
+func TestMergeCustomize(t *testing.T) {
+	base := map[string]CustomizeReqAndRes{
+		"/a": {
+			Headers:  map[string]string{"token": "string#base", "lang": "string#lang"},
+			Params:   []ParamSpec{{Name: "X-Trace", In: "header"}, {Name: "page", In: "query"}},
+			Request:  []string{"id"},
+			Response: []string{"data"},
+			Rename:   map[string]string{"uid": "userId"},
+			Fields:   map[string]FieldOverride{"name": {Desc: "名称"}},
+			Hide:     true,
+			Summary:  "基础标题",
+			Note:     "基础说明",
+		},
+		"/only-base": {Summary: "base"},
+	}
+	overlay := map[string]CustomizeReqAndRes{
+		"/a": {
+			Headers:  map[string]string{"token": "string#overlay"},
+			Params:   []ParamSpec{{Name: "x-trace", Type: "integer"}, {Name: "size", In: "query"}},
+			Response: []string{},
+			Rename:   map[string]string{"gid": "groupId"},
+			Summary:  "文件标题",
+		},
+		"/only-file": {Hide: true},
+	}
+	got := MergeCustomize(base, overlay)
+	a := got["/a"]
+	want := CustomizeReqAndRes{
+		Headers: map[string]string{"token": "string#overlay", "lang": "string#lang"},
+		// Header 参数名忽略大小写，按位置替换
+		Params:   []ParamSpec{{Name: "x-trace", Type: "integer"}, {Name: "page", In: "query"}, {Name: "size", In: "query"}},
+		Request:  []string{"id"},
+		Response: []string{},
+		Rename:   map[string]string{"uid": "userId", "gid": "groupId"},
+		Fields:   map[string]FieldOverride{"name": {Desc: "名称"}},
+		Hide:     true,
+		Summary:  "文件标题",
+		Note:     "基础说明",
+	}
+	if !reflect.DeepEqual(a, want) {
+		t.Errorf("merged /a = %+v, want %+v", a, want)
+	}
+	if got["/only-base"].Summary != "base" || !got["/only-file"].Hide || len(got) != 3 {
+		t.Errorf("merged = %+v", got)
+	}
+	// 入参不变
+	if base["/a"].Headers["token"] != "string#base" || len(base["/a"].Params) != 2 || len(base["/a"].Rename) != 1 || len(base) != 2 {
+		t.Errorf("base modified: %+v", base)
+	}
+}
+
+func TestLoadCustomizeFileParamLocations(t *testing.T) {
+	rules, err := LoadCustomizeFile(writeCustomizeFile(t, "params.yaml", `/a:
+  params:
+    - {name: page, in: Query}
+    - {name: X-Trace}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps := rules["/a"].Params; len(ps) != 2 || ps[0].In != "query" || ps[1].In != "header" {
+		t.Errorf("params = %+v", ps)
+	}
+	_, err = LoadCustomizeFile(writeCustomizeFile(t, "bad.yaml", "/a:\n  params:\n    - {name: payload, in: body}\n"))
+	if err == nil || !strings.Contains(err.Error(), `params[0].in: invalid location "body"`) {
+		t.Errorf("err = %v", err)
+	}
+}
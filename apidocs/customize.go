@@ -0,0 +1,79 @@
+package apidocs
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/config"
+	"github.com/megatrZlp/go-apidocs/apidocs/render"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfsnotify"
+)
+
+// customizeReloadDelay 定制规则文件变更后延迟加载的时间，期间的后续变更会重新计时。
+const customizeReloadDelay = 200 * time.Millisecond
+
+// watchCustomize 加载 Config.CustomizeFile 并监听其变更。
+// 说明：
+// - 监听文件所在目录（非递归），兼容编辑器“写临时文件再重命名”的保存方式；
+// - 连续事件合并为一次加载（customizeReloadDelay），避免读取到截断中的半成品文件；
+// - 加载或校验失败时记录错误日志，并保留上一次生效的规则（首次失败则仅使用 Config.Customize）。
+func (srv *Server) watchCustomize(c config.Config) {
+	if c.CustomizeFile == "" {
+		return
+	}
+	ctx := context.Background()
+	file, err := filepath.Abs(c.CustomizeFile)
+	if err != nil {
+		g.Log().Errorf(ctx, "apidocs: customize file %s: %v", c.CustomizeFile, err)
+		return
+	}
+	srv.reloadCustomize(ctx, c, file)
+	var (
+		mu    sync.Mutex
+		timer *time.Timer
+	)
+	_, err = gfsnotify.Add(filepath.Dir(file), func(e *gfsnotify.Event) {
+		if filepath.Clean(e.Path) != file || e.IsChmod() {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(customizeReloadDelay, func() { srv.reloadCustomize(ctx, c, file) })
+	}, gfsnotify.WatchOption{NoRecursive: true})
+	if err != nil {
+		g.Log().Errorf(ctx, "apidocs: watch customize file %s: %v", file, err)
+	}
+}
+
+// reloadCustomize 重新读取定制规则文件并与 Config.Customize 合并（同一路径下文件优先）。
+func (srv *Server) reloadCustomize(ctx context.Context, c config.Config, file string) {
+	rules, err := config.LoadCustomizeFile(file)
+	if err != nil {
+		g.Log().Errorf(ctx, "apidocs: customize file not applied, keeping previous rules:\n%v", err)
+		return
+	}
+	merged := config.MergeCustomize(c.Customize, rules)
+	srv.mu.Lock()
+	srv.customize = merged
+	srv.mu.Unlock()
+	// 旧规则键的解析结果不再使用，清空后按新规则按需解析
+	render.ResetSelectorCache()
+	g.Log().Infof(ctx, "apidocs: loaded %d customize rules from %s", len(rules), file)
+}
+
+// customizeRules 返回当前生效的定制规则。
+func (srv *Server) customizeRules(c config.Config) map[string]config.CustomizeReqAndRes {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+	if srv.customize != nil {
+		return srv.customize
+	}
+	return c.Customize
+}
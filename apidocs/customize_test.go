@@ -0,0 +1,53 @@
+package apidocs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/config"
+)
+
+func TestReloadCustomize(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "rules.yaml")
+	c := config.Config{Customize: map[string]config.CustomizeReqAndRes{
+		"/a": {Summary: "配置标题", Note: "配置说明"},
+		"/b": {Hide: true},
+	}}
+	srv := &Server{}
+	if got := srv.customizeRules(c); got["/a"].Summary != "配置标题" {
+		t.Fatalf("rules before loading = %+v", got)
+	}
+
+	write := func(content string) {
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 首次加载失败时仍使用 Config.Customize
+	write("customize:\n  /a:\n    hide: yes-please\n")
+	srv.reloadCustomize(ctx, c, file)
+	if srv.customize != nil {
+		t.Fatalf("invalid file applied: %+v", srv.customize)
+	}
+
+	// 同一路径下文件优先，其余规则保留
+	write("customize:\n  /a:\n    summary: 文件标题\n  /c:\n    hide: true\n")
+	srv.reloadCustomize(ctx, c, file)
+	got := srv.customizeRules(c)
+	if a := got["/a"]; a.Summary != "文件标题" || a.Note != "配置说明" || !got["/b"].Hide || !got["/c"].Hide {
+		t.Fatalf("merged rules = %+v", got)
+	}
+
+	// 之后的加载失败保留上一次生效的规则
+	write("customize:\n  /a: [1]\n")
+	srv.reloadCustomize(ctx, c, file)
+	if got = srv.customizeRules(c); got["/a"].Summary != "文件标题" || !got["/c"].Hide {
+		t.Errorf("rules after a failed reload = %+v", got)
+	}
+	if c.Customize["/a"].Summary != "配置标题" {
+		t.Errorf("Config.Customize modified: %+v", c.Customize)
+	}
+}
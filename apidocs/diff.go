@@ -0,0 +1,71 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/config"
+	"github.com/megatrZlp/go-apidocs/apidocs/render"
+	"github.com/megatrZlp/go-apidocs/apidocs/source"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// serveDiff 输出两份规范的变更报告。
+// 参数：
+// - base: 基线（旧）规范的数据源，必填，支持本地路径、file:// 与 http(s)
+// - target: 目标（新）规范的数据源，缺省时使用默认规范
+// - format: html（默认）、md 或 json；json 适用于 CI 卡点
+// 两份规范均按当前受众裁剪后再比较；响应头 X-Breaking-Changes 返回破坏性变更数量。
+func serveDiff(r *ghttp.Request, srv *Server, c config.Config) {
+	baseSrc := r.Get("base").String()
+	if baseSrc == "" {
+		r.Response.WriteStatus(400, "missing base")
+		return
+	}
+	base, baseRaw, err := source.LoadSpecFromSource(baseSrc)
+	if err != nil {
+		r.Response.WriteStatus(400, "load base: "+err.Error())
+		return
+	}
+	targetSrc := r.Get("target").String()
+	var target *gjson.Json
+	var targetRaw string
+	if targetSrc != "" {
+		if target, targetRaw, err = source.LoadSpecFromSource(targetSrc); err != nil {
+			r.Response.WriteStatus(400, "load target: "+err.Error())
+			return
+		}
+	} else {
+		target, targetRaw = srv.defaultSpec(c)
+		if target == nil {
+			r.Response.WriteStatus(400, "missing target")
+			return
+		}
+	}
+	audience := requestAudience(r, c)
+	rc := srv.renderConfig(r, c)
+	rep := render.DiffSpecsWithConfig(render.FilterAudience(base, audience), baseRaw, render.FilterAudience(target, audience), targetRaw, rc)
+	rep.Base = baseSrc
+	rep.Target = targetSrc
+	r.Response.Header().Set("X-Breaking-Changes", fmt.Sprintf("%d", rep.Breaking))
+	switch r.Get("format").String() {
+	case "json":
+		bs, _ := json.MarshalIndent(rep, "", "  ")
+		r.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
+		r.Response.Write(bs)
+	case "md":
+		r.Response.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		r.Response.Write(render.RenderDiffMarkdown(rep, rc))
+	default:
+		// 链接到文档页：锚点按默认规范（文档页不带 src 时展示的规范）与当前受众计算
+		docSpec, docRaw := target, targetRaw
+		if targetSrc != "" {
+			docSpec, docRaw = srv.defaultSpec(c)
+		}
+		anchors := render.OperationAnchors(render.FilterAudience(docSpec, audience), docRaw, rc)
+		r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+		r.Response.Write(render.GenerateDiffHTML(rep, c.RouteDocs, anchors, rc))
+	}
+}
@@ -0,0 +1,74 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/config"
+	"github.com/megatrZlp/go-apidocs/apidocs/render"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// MockExampleHeader 请求头：指定返回 content.<媒体类型>.examples 中的命名示例。
+const MockExampleHeader = "X-Mock-Example"
+
+// registerMock 在 RouteMock 前缀下绑定规范中的所有路径与方法，返回与文档一致的示例响应。
+// 说明：
+// - 规范来源为默认规范，为空且配置了 Domain/Port/Path 时拉取不带查询参数的远程源；不受文档页 ?src= 与转发参数影响；
+// - 路由表在注册时编译（远程源在首次成功拉取时编译），之后每个请求只做匹配；
+// - 路径参数按规范声明的 schema 校验，失败返回 400；未声明的路径返回 404，未声明的方法返回 405；
+// - 按 Config.Audience（或 ?audience=）裁剪规范，并应用与文档页一致的定制规则与废弃隐藏，对当前受众不可见或被隐藏的接口返回 404/405；
+// - 响应头 X-Mock-Path 返回命中的路径模板，便于前端排查。
+func registerMock(s *ghttp.Server, srv *Server, c config.Config) {
+	prefix := strings.TrimRight(c.RouteMock, "/")
+	srv.mockSpec(c)
+	s.BindHandler(prefix+"/*any", func(r *ghttp.Request) {
+		m := srv.mockSpec(c)
+		if m == nil {
+			r.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
+			r.Response.WriteStatus(503, `{"message":"no OpenAPI spec loaded"}`)
+			return
+		}
+		spec := render.FilterAudience(m.spec, requestAudience(r, c))
+		reqPath := strings.TrimPrefix(r.URL.Path, prefix)
+		res, err := m.routes.Response(spec, srv.renderConfig(r, c), r.Method, reqPath, r.Header.Get(MockExampleHeader))
+		if err != nil {
+			status := 500
+			var me *render.MockError
+			if errors.As(err, &me) {
+				status = me.Status
+			}
+			bs, _ := json.Marshal(map[string]string{"message": err.Error()})
+			r.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
+			r.Response.WriteStatus(status, bs)
+			return
+		}
+		r.Response.Header().Set("X-Mock-Path", res.Path)
+		if res.ContentType != "" {
+			r.Response.Header().Set("Content-Type", res.ContentType)
+		}
+		r.Response.WriteStatus(res.Status, res.Body)
+	})
+}
+
+// mockSpec 返回模拟服务的规范与路由表；尚未加载时按 defaultSpec 加载并编译，失败时返回 nil（下次请求重试）。
+func (srv *Server) mockSpec(c config.Config) *mockState {
+	srv.mu.RLock()
+	m := srv.mock
+	srv.mu.RUnlock()
+	if m != nil {
+		return m
+	}
+	spec, raw := srv.defaultSpec(c)
+	if spec == nil {
+		return nil
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.mock == nil {
+		srv.mock = &mockState{spec: spec, routes: render.NewMock(spec, raw)}
+	}
+	return srv.mock
+}
@@ -18,3 +18,10 @@ func HTML(spec *gjson.Json, raw string) string { return render.GenerateHTML(spec
 // - raw: 规范的原始 JSON 文本，用于保持 paths 原始顺序（必须与 spec 一致）
 // 返回：完整 Markdown 文本
 func Markdown(spec *gjson.Json, raw string) string { return render.GenerateMarkdown(spec, raw) }
+
+// FilterAudience 按受众裁剪规范：移除 x-audience 未包含该受众、或 x-internal: true（受众非 internal 时）的
+// 接口、标签、参数与字段，返回裁剪后的副本；audience 为空时原样返回。
+// 裁剪不改变 paths 的键，raw 仍可用于保持原始顺序。
+func FilterAudience(spec *gjson.Json, audience string) *gjson.Json {
+	return render.FilterAudience(spec, audience)
+}
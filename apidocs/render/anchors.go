@@ -0,0 +1,263 @@
+package render
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// anchorRegistry 页面内锚点的登记表：保证锚点唯一（重复时依次追加 -2、-3…），并记录旧锚点到新锚点的跳转。
+// 锚点按确定的顺序登记（接口按 paths 原始顺序与方法名，分组按首次出现顺序），与展示顺序、分组策略无关。
+// 接口区块各小节的锚点（<接口锚点>-<小节>，见 endpointSections）与页面中的固定 id 一并登记，不会与其他锚点重复。
+type anchorRegistry struct {
+	used map[string]bool
+	// reserved 各接口基础锚点的小节锚点，在分配接口锚点前预留，避免如 GET /a/url 占用 GET /a 的 get-a-url 小节
+	reserved map[string]bool
+	legacy   map[string]string
+}
+
+// endpointSections endpoint 模板中接口区块各小节锚点的后缀。
+var endpointSections = []string{
+	"url", "method", "security", "headers", "path-params", "query-params", "cookie-params",
+	"req-example", "req", "res-example", "res-params",
+}
+
+// pageFixedIDs 页面模板中固定使用的 id（main_header、nav 模板）。
+var pageFixedIDs = []string{"exportMd", "servers", "authentication", "expandAll", "collapseAll"}
+
+func newAnchorRegistry() *anchorRegistry {
+	r := &anchorRegistry{used: map[string]bool{}, reserved: map[string]bool{}, legacy: map[string]string{}}
+	for _, id := range pageFixedIDs {
+		r.used[id] = true
+	}
+	return r
+}
+
+// claim 登记锚点：base 未被占用（也未被预留为小节锚点）时直接使用，否则追加最小可用的数字后缀。
+func (r *anchorRegistry) claim(base string) string {
+	id := base
+	for n := 2; r.used[id] || r.reserved[id]; n++ {
+		id = base + "-" + strconv.Itoa(n)
+	}
+	r.used[id] = true
+	return id
+}
+
+// reserveSections 预留接口基础锚点 base 的各小节锚点。
+func (r *anchorRegistry) reserveSections(base string) {
+	for _, s := range endpointSections {
+		r.reserved[base+"-"+s] = true
+	}
+}
+
+// claimEndpoint 登记接口锚点及其各小节锚点：接口锚点或任一小节锚点已被占用时追加最小可用的数字后缀。
+func (r *anchorRegistry) claimEndpoint(base string) string {
+	free := func(id string) bool {
+		if r.used[id] || r.reserved[id] {
+			return false
+		}
+		for _, s := range endpointSections {
+			if r.used[id+"-"+s] {
+				return false
+			}
+		}
+		return true
+	}
+	id := base
+	for n := 2; !free(id); n++ {
+		id = base + "-" + strconv.Itoa(n)
+	}
+	r.used[id] = true
+	for _, s := range endpointSections {
+		r.used[id+"-"+s] = true
+	}
+	return id
+}
+
+// redirect 记录旧锚点 old 跳转到 id（二者相同时忽略；同一旧锚点只记录首个目标）。
+func (r *anchorRegistry) redirect(old, id string) {
+	if old == "" || old == id {
+		return
+	}
+	if _, ok := r.legacy[old]; !ok {
+		r.legacy[old] = id
+	}
+}
+
+// redirects 返回仍需跳转的旧锚点（排除已被页面中其他元素占用的锚点），页面脚本在找不到目标时据此跳转。
+func (r *anchorRegistry) redirects() map[string]string {
+	res := make(map[string]string, len(r.legacy))
+	for old, id := range r.legacy {
+		if !r.used[old] {
+			res[old] = id
+		}
+	}
+	return res
+}
+
+// redirectsJSON 旧锚点跳转表的 JSON 文本（供 script 模板使用）。
+func (r *anchorRegistry) redirectsJSON() string {
+	bs, _ := json.Marshal(r.redirects())
+	return string(bs)
+}
+
+var operationIDAnchorInvalid = regexp.MustCompile(`[^A-Za-z0-9_\-.:]+`)
+
+// operationIDAnchor 由 operationId 生成锚点：保留大小写，非法字符替换为 -；结果为空时返回空串。
+func operationIDAnchor(id string) string {
+	return strings.Trim(operationIDAnchorInvalid.ReplaceAllString(strings.TrimSpace(id), "-"), "-")
+}
+
+// groupSlug 分组名称的锚点片段：slugify 结果为空（如仅含标点的名称）时以名称的哈希代替，避免不同名称得到相同锚点。
+func groupSlug(name string) string {
+	if s := slugify(name); s != "" {
+		return s
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return "g" + strconv.FormatUint(uint64(h.Sum32()), 16)
+}
+
+// pageAnchors 页面中分组与 x-tagGroups 项的锚点。
+type pageAnchors struct {
+	// groups 分组路径（“主”、“主/次”、“主/次/三级”…）到锚点
+	groups map[string]string
+	// tagGroups x-tagGroups 项的展示名称到锚点
+	tagGroups map[string]string
+	// ops 接口（"METHOD path"，方法大写）到首次出现处的锚点；bases 为其登记前的基础锚点（未冲突时二者相同）
+	ops   map[string]string
+	bases map[string]string
+}
+
+// operation 接口在页面中的锚点（首次出现处）；接口不在页面中时按方法+路径生成。
+func (a pageAnchors) operation(method, path string) string {
+	if id, ok := a.ops[strings.ToUpper(method)+" "+path]; ok {
+		return id
+	}
+	return anchorID(strings.ToLower(method), path)
+}
+
+// docAnchors 按文档页的方式（相同的可见接口、分组策略与登记顺序）计算页面锚点，供检查报告与变更报告链接到文档页。
+func docAnchors(j *gjson.Json, contentRaw string, cfg RenderConfig) pageAnchors {
+	cat := loadTagCatalog(j, cfg.groupDelimiter())
+	ops := collectOperations(j, contentRaw, cfg, cat)
+	return cfg.assignAnchors(ops, len(cat.groups) > 0, newAnchorRegistry(), cfg.locale())
+}
+
+// OperationAnchors 返回文档页中各接口的锚点，键为 "METHOD path"（方法大写）；隐藏的接口不在其中。
+// j、contentRaw 与 cfg 应与渲染文档页时一致（含受众裁剪），变更报告据此链接到文档页。
+func OperationAnchors(j *gjson.Json, contentRaw string, cfg RenderConfig) map[string]string {
+	if j == nil {
+		return map[string]string{}
+	}
+	return docAnchors(j, contentRaw, cfg).ops
+}
+
+// group 分组路径对应的锚点；未登记时按旧规则生成。
+func (a pageAnchors) group(path string) string {
+	if id, ok := a.groups[path]; ok {
+		return id
+	}
+	parts := strings.Split(path, "/")
+	id := "group-" + slugify(parts[0])
+	if len(parts) > 1 {
+		id += "-" + slugify(strings.Join(parts[1:], "/"))
+	}
+	return id
+}
+
+// tagGroup x-tagGroups 项对应的锚点；未登记时按旧规则生成。
+func (a pageAnchors) tagGroup(name string) string {
+	if id, ok := a.tagGroups[name]; ok {
+		return id
+	}
+	return "tag-group-" + slugify(name)
+}
+
+// groupPath 一级分组与次级分组（可含多级，以 "/" 分隔）拼接为分组路径，各段去除首尾空白并忽略空段，与导航树一致。
+func groupPath(pre, suf string) string {
+	path := pre
+	for _, seg := range strings.Split(suf, "/") {
+		if seg = strings.TrimSpace(seg); seg != "" {
+			path += "/" + seg
+		}
+	}
+	return path
+}
+
+// assignAnchors 为 x-tagGroups 项、分组与接口分配唯一锚点，并登记旧锚点的跳转：
+// - x-tagGroups 项为 tag-group-<slug>（仅 tagGroups 为 true 时），分组为 group-<一级>-<次级>…，各段经 groupSlug 处理，按首次出现的顺序登记；
+// - 接口锚点缺省为方法+路径（tools.AnchorID）；AnchorOperationID 为 true 且声明了 operationId 时使用 operationId；
+// - 接口按 paths 原始顺序与方法名登记，与分组策略和排序无关；同一接口列在多个分组下时，首次出现处使用登记的锚点，其后各处依次追加后缀；
+// - 接口锚点与其各小节锚点（<接口锚点>-url 等）一并登记，基础锚点与其他接口的小节锚点相同时追加后缀。
+func (cfg RenderConfig) assignAnchors(ops []docOperation, tagGroups bool, reg *anchorRegistry, loc Locale) pageAnchors {
+	a := pageAnchors{groups: map[string]string{}, tagGroups: map[string]string{}, ops: map[string]string{}, bases: map[string]string{}}
+	for _, op := range ops {
+		if tagGroups {
+			name := firstNonEmpty(op.TagGroup, loc.T("other_tags"))
+			if _, ok := a.tagGroups[name]; !ok {
+				a.tagGroups[name] = reg.claim("tag-group-" + groupSlug(name))
+				reg.redirect("tag-group-"+slugify(name), a.tagGroups[name])
+			}
+		}
+		path, acc := "", "group"
+		for _, seg := range strings.Split(groupPath(op.Pre, op.Suf), "/") {
+			if path == "" {
+				path = seg
+			} else {
+				path += "/" + seg
+			}
+			if _, ok := a.groups[path]; !ok {
+				a.groups[path] = reg.claim(acc + "-" + groupSlug(seg))
+				reg.redirect(pageAnchors{}.group(path), a.groups[path])
+			}
+			acc = a.groups[path]
+		}
+	}
+	// 稳定排序：同一接口的多处按展示顺序排列，首个即首次出现处
+	idx := make([]int, len(ops))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(x, y int) bool {
+		p, q := ops[idx[x]].order, ops[idx[y]].order
+		if p.pathIndex != q.pathIndex {
+			return p.pathIndex < q.pathIndex
+		}
+		return p.method < q.method
+	})
+	base := func(op docOperation) string {
+		if cfg.AnchorOperationID {
+			if id := operationIDAnchor(op.Op.Get("operationId").String()); id != "" {
+				return id
+			}
+		}
+		return anchorID(op.Method, op.Path)
+	}
+	// 先预留全部接口的小节锚点，再分配接口锚点，结果与登记顺序无关
+	for _, op := range ops {
+		reg.reserveSections(base(op))
+	}
+	for _, i := range idx {
+		op := &ops[i]
+		key := strings.ToUpper(op.Method) + " " + op.Path
+		if _, ok := a.ops[key]; ok {
+			continue
+		}
+		b := base(*op)
+		op.Anchor = reg.claimEndpoint(b)
+		a.ops[key], a.bases[key] = op.Anchor, b
+		reg.redirect(anchorID(op.Method, op.Path), op.Anchor)
+	}
+	for i := range ops {
+		if ops[i].Anchor == "" {
+			ops[i].Anchor = reg.claimEndpoint(a.ops[strings.ToUpper(ops[i].Method)+" "+ops[i].Path])
+		}
+	}
+	return a
+}
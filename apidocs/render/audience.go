@@ -0,0 +1,213 @@
+package render
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// AudienceInternal 内部受众；x-internal: true 的条目仅对该受众可见。
+const AudienceInternal = "internal"
+
+// FilterAudience 返回按受众裁剪后的规范副本（原规范不变）；audience 为空时原样返回 j。
+// 可见性规则（作用于操作、路径项、标签、参数与 schema 属性）：
+// - 声明了 x-audience（字符串或数组）时，仅对其中列出的受众可见（忽略大小写）；
+// - 否则 x-internal: true 仅对 internal 受众可见；
+// - 其余条目对所有受众可见。
+// 操作的任一标签不可见时，该操作一并移除；参数与属性为 $ref 时同时检查引用组件上的标记，
+// 被移除的属性同步从 required 中删除。paths 的键保留，便于按原始顺序渲染。
+func FilterAudience(j *gjson.Json, audience string) *gjson.Json {
+	audience = strings.TrimSpace(audience)
+	if j == nil || audience == "" {
+		return j
+	}
+	bs, err := j.ToJson()
+	if err != nil {
+		return j
+	}
+	var doc map[string]interface{}
+	if err = json.Unmarshal(bs, &doc); err != nil {
+		return j
+	}
+	a := audienceFilter{doc: doc, audience: audience, hiddenTags: map[string]bool{}}
+	a.pruneTags()
+	a.prunePaths()
+	a.prune(doc)
+	return gjson.New(doc)
+}
+
+// audienceFilter 在解码后的规范树上执行裁剪。
+type audienceFilter struct {
+	doc        map[string]interface{}
+	audience   string
+	hiddenTags map[string]bool
+}
+
+// visible 判断节点对当前受众是否可见；节点为 $ref 时同时检查引用目标。
+func (a audienceFilter) visible(node interface{}) bool {
+	return a.visibleRef(node, map[string]bool{})
+}
+
+// visibleRef 同 visible；seen 记录已展开的 $ref，防止循环引用（A → B → A）无限递归。
+func (a audienceFilter) visibleRef(node interface{}, seen map[string]bool) bool {
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		return true
+	}
+	if r, ok := m["$ref"].(string); ok && !seen[r] {
+		seen[r] = true
+		if target := a.resolve(r); target != nil && !a.visibleRef(target, seen) {
+			return false
+		}
+	}
+	switch v := m["x-audience"].(type) {
+	case string:
+		return strings.EqualFold(strings.TrimSpace(v), a.audience)
+	case []interface{}:
+		for _, it := range v {
+			if s, ok := it.(string); ok && strings.EqualFold(strings.TrimSpace(s), a.audience) {
+				return true
+			}
+		}
+		return false
+	}
+	if internal, _ := m["x-internal"].(bool); internal {
+		return strings.EqualFold(a.audience, AudienceInternal)
+	}
+	return true
+}
+
+// resolve 解析文档内的 #/components/... 引用；无法解析时返回 nil。
+func (a audienceFilter) resolve(ref string) interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var cur interface{} = a.doc
+	for _, seg := range strings.Split(ref[2:], "/") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		seg = strings.ReplaceAll(strings.ReplaceAll(seg, "~1", "/"), "~0", "~")
+		if cur, ok = m[seg]; !ok {
+			return nil
+		}
+	}
+	return cur
+}
+
+// pruneTags 移除不可见的顶层标签定义，并记录其名称。
+func (a audienceFilter) pruneTags() {
+	tags, ok := a.doc["tags"].([]interface{})
+	if !ok {
+		return
+	}
+	kept := make([]interface{}, 0, len(tags))
+	for _, t := range tags {
+		if a.visible(t) {
+			kept = append(kept, t)
+			continue
+		}
+		if m, ok := t.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				a.hiddenTags[name] = true
+			}
+		}
+	}
+	a.doc["tags"] = kept
+}
+
+// prunePaths 移除不可见的操作：路径项不可见时移除其全部操作，操作本身或其任一标签不可见时移除该操作。
+func (a audienceFilter) prunePaths() {
+	paths, ok := a.doc["paths"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for p, item := range paths {
+		pm, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		itemVisible := a.visible(pm)
+		for k, op := range pm {
+			if !isHTTPMethod(k) {
+				continue
+			}
+			if !itemVisible || !a.visible(op) || a.hasHiddenTag(op) {
+				delete(pm, k)
+			}
+		}
+		if !itemVisible {
+			paths[p] = map[string]interface{}{}
+		}
+	}
+}
+
+// hasHiddenTag 判断操作是否带有不可见的标签。
+func (a audienceFilter) hasHiddenTag(op interface{}) bool {
+	m, ok := op.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	tags, _ := m["tags"].([]interface{})
+	for _, t := range tags {
+		if s, ok := t.(string); ok && a.hiddenTags[s] {
+			return true
+		}
+	}
+	return false
+}
+
+// prune 递归移除不可见的参数（parameters 数组）与 schema 属性（properties），跳过示例值。
+func (a audienceFilter) prune(node interface{}) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if params, ok := n["parameters"].([]interface{}); ok {
+			kept := make([]interface{}, 0, len(params))
+			for _, p := range params {
+				if a.visible(p) {
+					kept = append(kept, p)
+				}
+			}
+			n["parameters"] = kept
+		}
+		if props, ok := n["properties"].(map[string]interface{}); ok {
+			removed := make(map[string]bool)
+			for k, v := range props {
+				if !a.visible(v) {
+					delete(props, k)
+					removed[k] = true
+				}
+			}
+			if req, ok := n["required"].([]interface{}); ok && len(removed) > 0 {
+				kept := make([]interface{}, 0, len(req))
+				for _, r := range req {
+					if s, ok := r.(string); !ok || !removed[s] {
+						kept = append(kept, r)
+					}
+				}
+				n["required"] = kept
+			}
+		}
+		for k, v := range n {
+			if k == "example" || k == "examples" {
+				continue
+			}
+			a.prune(v)
+		}
+	case []interface{}:
+		for _, v := range n {
+			a.prune(v)
+		}
+	}
+}
+
+// isHTTPMethod 判断路径项的键是否为 HTTP 方法。
+func isHTTPMethod(k string) bool {
+	switch strings.ToLower(k) {
+	case "get", "post", "put", "delete", "patch", "options", "head", "trace":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// audienceSpec 覆盖 x-internal 与 x-audience 标记在标签、路径项、操作、参数与属性（含 $ref）上的组合。
+const audienceSpec = `{"tags":[{"name":"Public"},{"name":"Ops","x-internal":true},{"name":"Partner","x-audience":["partner","internal"]}],
+	"paths":{
+		"/users":{"get":{"tags":["Public"],
+			"parameters":[{"name":"page","in":"query"},{"name":"debug","in":"query","x-internal":true},{"$ref":"#/components/parameters/Trace"}],
+			"responses":{"200":{"content":{"application/json":{
+				"example":{"id":1,"secret":"s"},
+				"schema":{"type":"object","required":["id","secret","score"],"properties":{
+					"id":{"type":"integer"},
+					"secret":{"type":"string","x-internal":true},
+					"score":{"$ref":"#/components/schemas/Score"}}}}}}}},
+			"delete":{"tags":["Public"],"x-audience":"internal","responses":{"200":{"description":"ok"}}}},
+		"/metrics":{"get":{"tags":["Ops"],"responses":{"200":{"description":"ok"}}}},
+		"/deals":{"get":{"tags":["Partner"],"responses":{"200":{"description":"ok"}}}},
+		"/admin":{"x-internal":true,"get":{"responses":{"200":{"description":"ok"}}}}},
+	"components":{
+		"parameters":{"Trace":{"name":"X-Trace","in":"header","x-audience":"partner"}},
+		"schemas":{"Score":{"type":"number","x-internal":true}}}}`
+
+// audienceView 裁剪结果的摘要：可见的标签、操作、/users get 的参数、返回字段与 required。
+func audienceView(j *gjson.Json) map[string]string {
+	var tags, ops, params, props []string
+	for _, t := range j.GetJsons("tags") {
+		tags = append(tags, t.Get("name").String())
+	}
+	for _, p := range []string{"/users", "/metrics", "/deals", "/admin"} {
+		for _, m := range presentMethods(j.GetJsonMap("paths")[p]) {
+			ops = append(ops, strings.ToUpper(m)+" "+p)
+		}
+	}
+	users := j.GetJsonMap("paths")["/users"]
+	for _, p := range users.GetJsons("get.parameters") {
+		params = append(params, p.Get("name").String()+p.Get("$ref").String())
+	}
+	schema := users.GetJson("get.responses.200.content.application/json.schema")
+	for _, k := range sortedKeys(schema.GetJsonMap("properties")) {
+		props = append(props, k)
+	}
+	return map[string]string{
+		"tags":     strings.Join(tags, ","),
+		"ops":      strings.Join(ops, ","),
+		"params":   strings.Join(params, ","),
+		"props":    strings.Join(props, ","),
+		"required": strings.Join(schema.Get("required").Strings(), ","),
+	}
+}
+
+func sortedKeys(m map[string]*gjson.Json) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sortStrings(keys)
+	return keys
+}
+
+func TestFilterAudience(t *testing.T) {
+	cases := []struct {
+		audience string
+		want     map[string]string
+	}{
+		{"", map[string]string{
+			"tags": "Public,Ops,Partner", "ops": "DELETE /users,GET /users,GET /metrics,GET /deals,GET /admin",
+			"params": "page,debug,#/components/parameters/Trace", "props": "id,score,secret", "required": "id,secret,score"}},
+		{"public", map[string]string{
+			"tags": "Public", "ops": "GET /users",
+			"params": "page", "props": "id", "required": "id"}},
+		// x-audience 忽略大小写；Partner 标签对 partner 可见
+		{"PARTNER", map[string]string{
+			"tags": "Public,Partner", "ops": "GET /users,GET /deals",
+			"params": "page,#/components/parameters/Trace", "props": "id", "required": "id"}},
+		// internal 可见 x-internal 条目，但 x-audience 未列出 internal 的参数仍被移除
+		{"internal", map[string]string{
+			"tags": "Public,Ops,Partner", "ops": "DELETE /users,GET /users,GET /metrics,GET /deals,GET /admin",
+			"params": "page,debug", "props": "id,score,secret", "required": "id,secret,score"}},
+	}
+	for _, c := range cases {
+		j := gjson.New(audienceSpec)
+		got := audienceView(FilterAudience(j, c.audience))
+		for k, want := range c.want {
+			if got[k] != want {
+				t.Errorf("audience %q %s = %q, want %q", c.audience, k, got[k], want)
+			}
+		}
+		// 原规范不变
+		if c.audience != "" && audienceView(j)["props"] != "id,score,secret" {
+			t.Errorf("audience %q modified the original spec", c.audience)
+		}
+	}
+
+	// 示例值不裁剪；被移除的路径项保留键
+	f := FilterAudience(gjson.New(audienceSpec), "public")
+	users := f.GetJsonMap("paths")["/users"]
+	if users.Get("get.responses.200.content.application/json.example.secret").String() != "s" {
+		t.Errorf("example was pruned: %s", users.MustToJsonString())
+	}
+	if _, ok := f.GetJsonMap("paths")["/admin"]; !ok {
+		t.Errorf("hidden path item key removed")
+	}
+	if FilterAudience(nil, "public") != nil {
+		t.Errorf("FilterAudience(nil) != nil")
+	}
+}
+
+func TestFilterAudienceHTML(t *testing.T) {
+	j := FilterAudience(gjson.New(audienceSpec), "public")
+	page := GenerateHTMLWithConfig(j, audienceSpec, RenderConfig{})
+	for _, hidden := range []string{"debug", "secret", "/metrics", "/admin", "Ops"} {
+		if strings.Contains(page, hidden) {
+			t.Errorf("public page shows %q", hidden)
+		}
+	}
+	if !strings.Contains(page, "/users") || !strings.Contains(page, "page") {
+		t.Errorf("public page lost visible items")
+	}
+}
+
+func TestFilterAudienceCyclicRefs(t *testing.T) {
+	raw := `{"paths":{"/a":{"get":{
+		"parameters":[{"$ref":"#/components/parameters/A"},{"name":"q","in":"query"}],
+		"responses":{"200":{"content":{"application/json":{"schema":{"type":"object","properties":{
+			"self":{"$ref":"#/components/schemas/Self"},
+			"loop":{"$ref":"#/components/schemas/A"},
+			"hidden":{"$ref":"#/components/schemas/Hidden"}}}}}}}}}},
+		"components":{
+			"parameters":{"A":{"$ref":"#/components/parameters/B"},"B":{"$ref":"#/components/parameters/A"}},
+			"schemas":{
+				"Self":{"$ref":"#/components/schemas/Self"},
+				"A":{"$ref":"#/components/schemas/B"},
+				"B":{"$ref":"#/components/schemas/A"},
+				"Hidden":{"$ref":"#/components/schemas/Loop","x-internal":true},
+				"Loop":{"$ref":"#/components/schemas/Hidden"}}}}`
+	f := FilterAudience(gjson.New(raw), "public")
+	op := f.GetJsonMap("paths")["/a"]
+	if n := len(op.GetJsons("get.parameters")); n != 2 {
+		t.Errorf("parameters = %d, want the cyclic ref kept as visible", n)
+	}
+	props := sortedKeys(op.GetJsonMap("get.responses.200.content.application/json.schema.properties"))
+	if got := strings.Join(props, ","); got != "loop,self" {
+		t.Errorf("properties = %s, want loop,self", got)
+	}
+}
@@ -0,0 +1,255 @@
+package render
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// Constraints schema 上的取值约束，嵌入 FieldInfo 与 ParamInfo；字符串字段为空表示未设置。
+// - Enum/EnumNames/EnumDescs: 枚举值及其 x-enum-varnames、x-enum-descriptions 标注（与 Enum 下标对应）
+// - Minimum/Maximum: 数值范围，ExclusiveMinimum/ExclusiveMaximum 表示开区间（兼容 3.0 布尔与 3.1 数值写法）
+// - MinLength/MaxLength: 字符串长度；MinItems/MaxItems/UniqueItems: 数组元素约束
+// - Default: 默认值的 JSON 文本；Example: 示例值的 JSON 文本（目前仅 Customize 注入的参数设置）
+type Constraints struct {
+	Enum             []string
+	EnumNames        []string
+	EnumDescs        []string
+	Format           string
+	Pattern          string
+	Minimum          string
+	Maximum          string
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	MinLength        string
+	MaxLength        string
+	MinItems         string
+	MaxItems         string
+	UniqueItems      bool
+	Nullable         bool
+	Default          string
+	Example          string
+}
+
+// HasConstraints 是否设置了任一约束。
+func (c Constraints) HasConstraints() bool {
+	return len(c.Enum) > 0 || c.Format != "" || c.Pattern != "" || c.Minimum != "" || c.Maximum != "" ||
+		c.MinLength != "" || c.MaxLength != "" || c.MinItems != "" || c.MaxItems != "" ||
+		c.UniqueItems || c.Nullable || c.Default != "" || c.Example != ""
+}
+
+// schemaTypeName 返回 schema 的类型名；兼容 3.1 的类型数组（如 ["string","null"]），返回首个非 null 类型。
+func schemaTypeName(s *gjson.Json) string {
+	if s == nil {
+		return ""
+	}
+	v := s.Get("type")
+	if v.IsSlice() {
+		for _, t := range v.Strings() {
+			if t != "null" {
+				return t
+			}
+		}
+		return ""
+	}
+	return v.String()
+}
+
+// schemaConstraints 读取 schema 上的约束；$ref 指向的组件（如枚举类型）会被解析。
+func schemaConstraints(j *gjson.Json, s *gjson.Json) Constraints {
+	var c Constraints
+	if s == nil || s.IsNil() {
+		return c
+	}
+	if r := s.Get("$ref").String(); r != "" {
+		c = schemaConstraints(j, getRefJson(j, r))
+		// 引用旁的 nullable/default 以引用处为准
+		if s.Get("nullable").Bool() {
+			c.Nullable = true
+		}
+		if d := jsonText(s.Get("default").Val()); d != "" {
+			c.Default = d
+		}
+		return c
+	}
+	c.Enum = s.Get("enum").Strings()
+	c.EnumNames = s.Get("x-enum-varnames").Strings()
+	c.EnumDescs = enumDescriptions(s, c.Enum)
+	c.Format = s.Get("format").String()
+	c.Pattern = s.Get("pattern").String()
+	c.Minimum = s.Get("minimum").String()
+	c.Maximum = s.Get("maximum").String()
+	// exclusiveMinimum：3.0 为布尔，3.1 为数值（本身即边界）
+	if v := s.Get("exclusiveMinimum"); !v.IsNil() {
+		if b, ok := v.Val().(bool); ok {
+			c.ExclusiveMinimum = b && c.Minimum != ""
+		} else {
+			c.Minimum, c.ExclusiveMinimum = v.String(), true
+		}
+	}
+	if v := s.Get("exclusiveMaximum"); !v.IsNil() {
+		if b, ok := v.Val().(bool); ok {
+			c.ExclusiveMaximum = b && c.Maximum != ""
+		} else {
+			c.Maximum, c.ExclusiveMaximum = v.String(), true
+		}
+	}
+	c.MinLength = s.Get("minLength").String()
+	c.MaxLength = s.Get("maxLength").String()
+	c.MinItems = s.Get("minItems").String()
+	c.MaxItems = s.Get("maxItems").String()
+	c.UniqueItems = s.Get("uniqueItems").Bool()
+	c.Nullable = s.Get("nullable").Bool()
+	if t := s.Get("type"); t.IsSlice() {
+		for _, x := range t.Strings() {
+			if x == "null" {
+				c.Nullable = true
+			}
+		}
+	}
+	c.Default = jsonText(s.Get("default").Val())
+	return c
+}
+
+// arrayConstraints 合并数组字段的约束：元素约束（枚举、格式、长度等）与数组自身的元素数、唯一性、nullable、默认值。
+func arrayConstraints(j *gjson.Json, arr *gjson.Json, items *gjson.Json) Constraints {
+	c := schemaConstraints(j, items)
+	a := schemaConstraints(j, arr)
+	c.MinItems, c.MaxItems, c.UniqueItems = a.MinItems, a.MaxItems, a.UniqueItems
+	c.Nullable, c.Default = a.Nullable, a.Default
+	return c
+}
+
+// enumDescriptions 读取 x-enum-descriptions：数组按下标对应，对象按枚举值对应。
+func enumDescriptions(s *gjson.Json, enum []string) []string {
+	v := s.Get("x-enum-descriptions")
+	if v.IsNil() {
+		return nil
+	}
+	if v.IsSlice() {
+		return v.Strings()
+	}
+	m := v.Map()
+	if len(m) == 0 {
+		return nil
+	}
+	res := make([]string, len(enum))
+	for i, e := range enum {
+		switch d := m[e].(type) {
+		case nil:
+		case string:
+			res[i] = d
+		default:
+			res[i] = jsonText(d)
+		}
+	}
+	return res
+}
+
+// jsonText 将默认值等任意值编码为紧凑 JSON 文本；nil 返回空串。
+func jsonText(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(bs)
+}
+
+// enumLabels 返回枚举值的展示文本：值后附 varname 与说明，例如 "1 (ACTIVE 启用)"。
+func enumLabels(c Constraints) []string {
+	res := make([]string, len(c.Enum))
+	for i, e := range c.Enum {
+		var notes []string
+		if i < len(c.EnumNames) && c.EnumNames[i] != "" {
+			notes = append(notes, c.EnumNames[i])
+		}
+		if i < len(c.EnumDescs) && c.EnumDescs[i] != "" {
+			notes = append(notes, c.EnumDescs[i])
+		}
+		res[i] = e
+		if len(notes) > 0 {
+			res[i] = e + " (" + strings.Join(notes, " ") + ")"
+		}
+	}
+	return res
+}
+
+// boundsText 渲染取值边界：两端均有时为区间写法 [1, 10]，单端时为 ≥ 1 / < 10。
+func boundsText(min, max string, exMin, exMax bool) string {
+	switch {
+	case min != "" && max != "":
+		l, r := "[", "]"
+		if exMin {
+			l = "("
+		}
+		if exMax {
+			r = ")"
+		}
+		return l + min + ", " + max + r
+	case min != "":
+		if exMin {
+			return "> " + min
+		}
+		return "≥ " + min
+	case max != "":
+		if exMax {
+			return "< " + max
+		}
+		return "≤ " + max
+	}
+	return ""
+}
+
+// constraintParts 将约束渲染为若干条“名称: 值”文本，表格与模板共用。
+func constraintParts(c Constraints, loc Locale) []string {
+	var parts []string
+	if len(c.Enum) > 0 {
+		parts = append(parts, loc.T("c_enum")+": "+strings.Join(enumLabels(c), ", "))
+	}
+	if c.Format != "" {
+		parts = append(parts, loc.T("c_format")+": "+c.Format)
+	}
+	if c.Pattern != "" {
+		parts = append(parts, loc.T("c_pattern")+": "+c.Pattern)
+	}
+	if b := boundsText(c.Minimum, c.Maximum, c.ExclusiveMinimum, c.ExclusiveMaximum); b != "" {
+		parts = append(parts, loc.T("c_range")+": "+b)
+	}
+	if b := boundsText(c.MinLength, c.MaxLength, false, false); b != "" {
+		parts = append(parts, loc.T("c_length")+": "+b)
+	}
+	if b := boundsText(c.MinItems, c.MaxItems, false, false); b != "" {
+		parts = append(parts, loc.T("c_items")+": "+b)
+	}
+	if c.UniqueItems {
+		parts = append(parts, loc.T("c_unique"))
+	}
+	if c.Nullable {
+		parts = append(parts, loc.T("c_nullable"))
+	}
+	if c.Default != "" {
+		parts = append(parts, loc.T("c_default")+": "+c.Default)
+	}
+	if c.Example != "" {
+		parts = append(parts, loc.T("c_example")+": "+c.Example)
+	}
+	return parts
+}
+
+// constraintsHTML 约束单元格的 HTML（多条以换行分隔）。
+func constraintsHTML(c Constraints, loc Locale) string {
+	parts := constraintParts(c, loc)
+	for i := range parts {
+		parts[i] = htmlEscape(parts[i])
+	}
+	return strings.Join(parts, "<br>")
+}
+
+// constraintsMarkdown 约束单元格的 Markdown（转义竖线，多条以分号分隔）。
+func constraintsMarkdown(c Constraints, loc Locale) string {
+	return strings.ReplaceAll(strings.Join(constraintParts(c, loc), "; "), "|", "\\|")
+}
@@ -0,0 +1,71 @@
+package render
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+func TestSchemaConstraints(t *testing.T) {
+	j := gjson.New(`{"components":{"schemas":{"Status":{"type":"integer","enum":[1,2],"x-enum-varnames":["ON","OFF"],"x-enum-descriptions":{"2":"停用"}}}}}`)
+	cases := []struct {
+		name   string
+		schema string
+		want   Constraints
+	}{
+		{"3.0 boolean exclusiveMinimum", `{"type":"number","minimum":1,"exclusiveMinimum":true,"maximum":9,"exclusiveMaximum":false}`,
+			Constraints{Minimum: "1", Maximum: "9", ExclusiveMinimum: true}},
+		{"3.0 boolean without bound is ignored", `{"type":"number","exclusiveMinimum":true,"exclusiveMaximum":true}`,
+			Constraints{}},
+		{"3.1 numeric exclusive bounds", `{"type":"number","exclusiveMinimum":0,"exclusiveMaximum":100}`,
+			Constraints{Minimum: "0", Maximum: "100", ExclusiveMinimum: true, ExclusiveMaximum: true}},
+		{"3.1 numeric overrides minimum", `{"type":"number","minimum":1,"exclusiveMinimum":5}`,
+			Constraints{Minimum: "5", ExclusiveMinimum: true}},
+		{"string rules", `{"type":"string","format":"email","pattern":"^a","minLength":1,"maxLength":8,"default":"a"}`,
+			Constraints{Format: "email", Pattern: "^a", MinLength: "1", MaxLength: "8", Default: `"a"`}},
+		{"3.1 null type is nullable", `{"type":["string","null"]}`, Constraints{Nullable: true}},
+		{"enum via ref with local default", `{"$ref":"#/components/schemas/Status","default":2,"nullable":true}`,
+			Constraints{Enum: []string{"1", "2"}, EnumNames: []string{"ON", "OFF"}, EnumDescs: []string{"", "停用"}, Nullable: true, Default: "2"}},
+	}
+	for _, c := range cases {
+		if got := schemaConstraints(j, gjson.New(c.schema)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s:\n got %+v\nwant %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestArrayConstraints(t *testing.T) {
+	arr := gjson.New(`{"type":"array","minItems":1,"maxItems":3,"uniqueItems":true,"items":{"type":"string","enum":["a","b"],"maxLength":2}}`)
+	got := arrayConstraints(nil, arr, arr.GetJson("items"))
+	want := Constraints{Enum: []string{"a", "b"}, MaxLength: "2", MinItems: "1", MaxItems: "3", UniqueItems: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestConstraintParts(t *testing.T) {
+	cases := []struct {
+		c    Constraints
+		want string
+	}{
+		{Constraints{Minimum: "0", Maximum: "10", ExclusiveMinimum: true}, "range: (0, 10]"},
+		{Constraints{Minimum: "0", ExclusiveMinimum: true}, "range: > 0"},
+		{Constraints{Maximum: "5"}, "range: ≤ 5"},
+		{Constraints{Enum: []string{"1", "2"}, EnumNames: []string{"ON"}, EnumDescs: []string{"", "off"}}, "enum: 1 (ON), 2 (off)"},
+		{Constraints{MinItems: "1", UniqueItems: true, Nullable: true, Default: "[]"}, "items: ≥ 1; unique items; nullable; default: []"},
+	}
+	loc := RenderConfig{Lang: LangEn}.locale()
+	for _, c := range cases {
+		if got := constraintsMarkdown(c.c, loc); got != c.want {
+			t.Errorf("constraints %+v = %q, want %q", c.c, got, c.want)
+		}
+	}
+	if got := constraintsMarkdown(Constraints{Pattern: "a|b"}, loc); !strings.Contains(got, `a\|b`) {
+		t.Errorf("markdown must escape pipes: %q", got)
+	}
+	if got := constraintsHTML(Constraints{Pattern: "<a>", Format: "x"}, loc); got != "format: x<br>pattern: &lt;a&gt;" {
+		t.Errorf("html = %q", got)
+	}
+}
@@ -0,0 +1,510 @@
+package render
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+const customizeTestSpec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "t", "version": "1"},
+  "paths": {
+    "/v1/users/{userId}": {
+      "get": {
+        "operationId": "getUser",
+        "tags": ["Users"],
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Envelope"}}}},
+        "responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Envelope"}}}}}
+      },
+      "delete": {
+        "tags": ["Users"],
+        "responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Envelope"}}}}}
+      }
+    },
+    "/v1/users/{userId}/orders": {
+      "get": {
+        "tags": ["Orders"],
+        "responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Envelope"}}}}}
+      }
+    },
+    "/v2/admin/v1/users": {
+      "get": {
+        "tags": ["Admin"],
+        "responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Envelope"}}}}}
+      }
+    }
+  },
+  "components": {"schemas": {
+    "Envelope": {"type": "object", "properties": {
+      "code": {"type": "integer"},
+      "message": {"type": "string"},
+      "data": {"type": "object", "properties": {
+        "id": {"type": "string"},
+        "name": {"type": "string"},
+        "secret": {"type": "string"}
+      }}
+    }}
+  }}
+}`
+
+func loadCustomizeTestSpec(t *testing.T) *gjson.Json {
+	t.Helper()
+	j, err := gjson.LoadContent([]byte(customizeTestSpec))
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	return j
+}
+
+func TestCustomizeForSelectors(t *testing.T) {
+	j := loadCustomizeTestSpec(t)
+	cases := []struct {
+		name   string
+		key    string
+		path   string
+		method string
+		want   bool
+	}{
+		{"exact template ignores param name", "/v1/users/{id}", "/v1/users/{userId}", "get", true},
+		{"exact does not match sub path", "/v1/users/{id}", "/v1/users/{userId}/orders", "get", false},
+		{"single star stays in segment", "/v1/users/*", "/v1/users/{userId}", "get", true},
+		{"single star does not cross segments", "/v1/users/*", "/v1/users/{userId}/orders", "get", false},
+		{"glob is anchored", "/v1/user*", "/v2/admin/v1/users", "get", false},
+		{"double star crosses segments", "/v1/**", "/v1/users/{userId}/orders", "get", true},
+		{"double star matches zero segments", "/v1/users/{id}/**", "/v1/users/{userId}", "get", true},
+		{"regex is anchored", "re:/v[12]/users", "/v2/admin/v1/users", "get", false},
+		{"regex", "re:/v2/.*/users", "/v2/admin/v1/users", "get", true},
+		{"method filter hit", "DELETE /v1/users/*", "/v1/users/{userId}", "delete", true},
+		{"method filter miss", "DELETE,PUT /v1/users/*", "/v1/users/{userId}", "get", false},
+		{"tag", "tag:Orders", "/v1/users/{userId}/orders", "get", true},
+		{"operationId", "operationId:getUser", "/v1/users/{userId}", "get", true},
+		{"operationId miss", "operationId:getUser", "/v1/users/{userId}", "delete", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := RenderConfig{Customize: map[string]CustomizeReqAndRes{c.key: {Headers: map[string]string{"X-Hit": "string"}}}}
+			op := j.GetJsonMap("paths")[c.path].GetJson(c.method)
+			got := customizeFor(c.path, c.method, op, cfg).Headers["X-Hit"] != ""
+			if got != c.want {
+				t.Fatalf("selector %q on %s %s: got %v, want %v", c.key, c.method, c.path, got, c.want)
+			}
+		})
+	}
+}
+
+func TestCustomizeForOverlappingPriority(t *testing.T) {
+	j := loadCustomizeTestSpec(t)
+	cfg := RenderConfig{Customize: map[string]CustomizeReqAndRes{
+		"/v1/**":              {Headers: map[string]string{"X-Who": "string#glob-deep", "X-All": "string#all"}, Response: []string{"name"}},
+		"/v1/users/*":         {Headers: map[string]string{"X-Who": "string#glob"}},
+		"/v1/users/{id}":      {Headers: map[string]string{"X-Who": "string#exact"}, Request: []string{"id"}},
+		"DELETE /v1/users/*":  {Headers: map[string]string{"X-Who": "string#glob-delete"}},
+		"tag:Users":           {Headers: map[string]string{"X-Who": "string#tag", "X-Tag": "string#tag"}, Request: []string{"secret"}},
+		"operationId:getUser": {Headers: map[string]string{"X-Who": "string#opid"}},
+	}}
+	paths := j.GetJsonMap("paths")
+	get := customizeFor("/v1/users/{userId}", "get", paths["/v1/users/{userId}"].GetJson("get"), cfg)
+	if got := get.Headers["X-Who"]; got != "string#opid" {
+		t.Errorf("get X-Who = %q, want operationId rule", got)
+	}
+	if got := get.Headers["X-All"]; got != "string#all" {
+		t.Errorf("get X-All = %q, want inherited from /v1/**", got)
+	}
+	if got := get.Headers["X-Tag"]; got != "string#tag" {
+		t.Errorf("get X-Tag = %q, want inherited from tag rule", got)
+	}
+	if !reflect.DeepEqual(get.Request, []string{"id"}) {
+		t.Errorf("get Request = %v, want exact rule whitelist only", get.Request)
+	}
+	if !reflect.DeepEqual(get.Response, []string{"name"}) {
+		t.Errorf("get Response = %v, want /v1/** whitelist", get.Response)
+	}
+	del := customizeFor("/v1/users/{userId}", "delete", paths["/v1/users/{userId}"].GetJson("delete"), cfg)
+	if got := del.Headers["X-Who"]; got != "string#exact" {
+		t.Errorf("delete X-Who = %q, want exact path over method-qualified glob", got)
+	}
+	orders := customizeFor("/v1/users/{userId}/orders", "get", paths["/v1/users/{userId}/orders"].GetJson("get"), cfg)
+	if got := orders.Headers["X-Who"]; got != "string#glob-deep" {
+		t.Errorf("orders X-Who = %q, want /v1/**", got)
+	}
+	// 多次解析结果一致（不依赖 map 遍历顺序）
+	for i := 0; i < 20; i++ {
+		again := customizeFor("/v1/users/{userId}", "get", paths["/v1/users/{userId}"].GetJson("get"), cfg)
+		if !reflect.DeepEqual(again, get) {
+			t.Fatalf("resolution is not deterministic: %v vs %v", again, get)
+		}
+	}
+}
+
+func TestBuildEndpointUsesResolvedRule(t *testing.T) {
+	j := loadCustomizeTestSpec(t)
+	paths := j.GetJsonMap("paths")
+	pj := paths["/v1/users/{userId}"]
+	fieldPaths := func(fs []FieldInfo) string {
+		var res []string
+		for _, f := range fs {
+			res = append(res, f.Path)
+		}
+		return strings.Join(res, ",")
+	}
+	cases := []struct {
+		name    string
+		rules   map[string]CustomizeReqAndRes
+		headers string
+		resp    string
+	}{
+		{
+			name:    "exact",
+			rules:   map[string]CustomizeReqAndRes{"/v1/users/{userId}": {Headers: map[string]string{"X-A": "string"}, Response: []string{"id"}}},
+			headers: "X-A",
+			resp:    "data,code,message,data.id",
+		},
+		{
+			name:    "wildcard",
+			rules:   map[string]CustomizeReqAndRes{"/v1/users/*": {Headers: map[string]string{"X-B": "string"}, Response: []string{"name"}}},
+			headers: "X-B",
+			resp:    "data,code,message,data.name",
+		},
+		{
+			name: "overlapping",
+			rules: map[string]CustomizeReqAndRes{
+				"/v1/**":              {Headers: map[string]string{"X-B": "string"}, Response: []string{"name"}},
+				"operationId:getUser": {Headers: map[string]string{"X-A": "string"}, Response: []string{"secret"}},
+			},
+			headers: "X-A,X-B",
+			resp:    "data,code,message,data.secret",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ep := buildEndpoint(j, "/v1/users/{userId}", "get", pj, pj.GetJson("get"), RenderConfig{Customize: c.rules})
+			var hs []string
+			for _, h := range ep.Headers {
+				hs = append(hs, h.Name)
+			}
+			if got := strings.Join(hs, ","); got != c.headers {
+				t.Errorf("headers = %s, want %s", got, c.headers)
+			}
+			if got := fieldPaths(ep.ResponseFields); got != c.resp {
+				t.Errorf("response fields = %s, want %s", got, c.resp)
+			}
+			if strings.Contains(ep.ResponseExample, "secret") != strings.Contains(c.resp, "secret") {
+				t.Errorf("response example not filtered consistently: %s", ep.ResponseExample)
+			}
+		})
+	}
+}
+
+func TestValidateSelector(t *testing.T) {
+	for _, key := range []string{"/users/{id}", "/v1/**", "GET,post /users/*", "re:^/v[12]/.*", "tag:用户", "operationId:getUser"} {
+		if err := ValidateSelector(key); err != nil {
+			t.Errorf("ValidateSelector(%q) = %v, want nil", key, err)
+		}
+	}
+	for _, key := range []string{"", "users", "re:(", "tag:", "GET"} {
+		if err := ValidateSelector(key); err == nil {
+			t.Errorf("ValidateSelector(%q) = nil, want error", key)
+		}
+	}
+}
+
+func TestResetSelectorCache(t *testing.T) {
+	cached := func() int {
+		n := 0
+		selectorCache.Range(func(_, _ any) bool { n++; return true })
+		return n
+	}
+	for _, key := range []string{"/reset/a", "re:^/reset/.*", "tag:reset"} {
+		if err := ValidateSelector(key); err != nil {
+			t.Fatalf("ValidateSelector(%q) = %v", key, err)
+		}
+	}
+	if cached() == 0 {
+		t.Fatal("selector cache empty after parsing")
+	}
+	ResetSelectorCache()
+	if n := cached(); n != 0 {
+		t.Fatalf("selector cache has %d entries after reset", n)
+	}
+	// 清空后按需重新解析
+	s, err := parseSelector("GET /reset/{id}")
+	if err != nil || !s.matches("/reset/1", "get", nil) {
+		t.Fatalf("parseSelector after reset = %+v, %v", s, err)
+	}
+}
+
+func TestFieldRules(t *testing.T) {
+	fr := fieldRules{
+		Exclude: []string{"secret", "data.list[].internal"},
+		Rename:  map[string]string{"uid": "userId", "data.list": "rows"},
+		Fields:  map[string]FieldOverride{"data.list[].status": {Desc: "状态", Type: "enum", Example: 1}},
+	}
+	fields := fr.applyToFields([]FieldInfo{
+		{Path: "secret", Type: "string"},
+		{Path: "data", Type: "object"},
+		{Path: "data.list[]", Type: "array(object)"},
+		{Path: "data.list[].uid", Type: "string"},
+		{Path: "data.list[].status", Type: "integer", Desc: "st"},
+		{Path: "data.list[].internal", Type: "object"},
+		{Path: "data.list[].internal.a", Type: "string"},
+	})
+	var got []string
+	for _, f := range fields {
+		got = append(got, f.Path+":"+f.Type+":"+f.Desc)
+	}
+	want := "data:object:,data.rows[]:array(object):,data.rows[].userId:string:,data.rows[].status:enum:状态"
+	if strings.Join(got, ",") != want {
+		t.Errorf("fields = %s, want %s", strings.Join(got, ","), want)
+	}
+	ex := fr.applyToExample(map[string]interface{}{
+		"secret": "x",
+		"data": map[string]interface{}{"list": []interface{}{
+			map[string]interface{}{"uid": "u", "status": 0, "internal": map[string]interface{}{"a": "b"}},
+		}},
+	}, "")
+	wantEx := map[string]interface{}{"data": map[string]interface{}{"rows": []interface{}{
+		map[string]interface{}{"userId": "u", "status": 1},
+	}}}
+	if !reflect.DeepEqual(ex, wantEx) {
+		t.Errorf("example = %v, want %v", ex, wantEx)
+	}
+}
+
+// itemsSpec data.items 为真实的数组属性，data.departments 为数组（可按 .items[] 写法引用），data.itemsCount 为普通属性。
+const itemsSpec = `{"paths":{"/list":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"type":"object","properties":{
+	"code":{"type":"integer"},
+	"data":{"type":"object","properties":{
+		"itemsCount":{"type":"integer","default":2},
+		"items":{"type":"array","items":{"type":"object","properties":{
+			"name":{"type":"string","default":"n"},
+			"completeCode":{"type":"string","default":"c"}}}},
+		"departments":{"type":"array","items":{"type":"object","properties":{
+			"address":{"type":"string","default":"a"},
+			"phone":{"type":"string","default":"p"}}}}}}}}}}}}}}}}`
+
+func TestFieldRulesItemsProperty(t *testing.T) {
+	j := gjson.New(itemsSpec)
+	pj := j.GetJsonMap("paths")["/list"]
+	cases := []struct {
+		name    string
+		rule    CustomizeReqAndRes
+		fields  string
+		example string
+	}{
+		{
+			name:    "exclude real items",
+			rule:    CustomizeReqAndRes{Exclude: []string{"data.items[].completeCode", "data.itemsCount"}},
+			fields:  "code,data,data.departments[],data.departments[].address,data.departments[].phone,data.items[],data.items[].name",
+			example: `{"code":0,"data":{"departments":[{"address":"a","phone":"p"}],"items":[{"name":"n"}]}}`,
+		},
+		{
+			name:    "whitelist real items",
+			rule:    CustomizeReqAndRes{Response: []string{"data.items[].name"}},
+			fields:  "code,data,data.items[].name",
+			example: `{"code":0,"data":{"items":[{"name":"n"}]}}`,
+		},
+		{
+			name:    "items notation for arrays",
+			rule:    CustomizeReqAndRes{Response: []string{"data.departments.items[].address", "data.itemsCount"}, Exclude: []string{"data.departments.items[].phone"}},
+			fields:  "code,data,data.departments[].address,data.itemsCount",
+			example: `{"code":0,"data":{"departments":[{"address":"a"}],"itemsCount":2}}`,
+		},
+		{
+			name:    "trailing items notation",
+			rule:    CustomizeReqAndRes{Exclude: []string{"data.departments.items"}, Rename: map[string]string{"data.items[].name": "title"}},
+			fields:  "code,data,data.itemsCount,data.items[],data.items[].completeCode,data.items[].title",
+			example: `{"code":0,"data":{"items":[{"completeCode":"c","title":"n"}],"itemsCount":2}}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ep := buildEndpoint(j, "/list", "get", pj, pj.GetJson("get"), RenderConfig{Customize: map[string]CustomizeReqAndRes{"/list": c.rule}})
+			var paths []string
+			for _, f := range ep.ResponseFields {
+				paths = append(paths, f.Path)
+			}
+			sort.Strings(paths)
+			if got := strings.Join(paths, ","); got != c.fields {
+				t.Errorf("fields = %s, want %s", got, c.fields)
+			}
+			var got, want interface{}
+			_ = json.Unmarshal([]byte(ep.ResponseExample), &got)
+			_ = json.Unmarshal([]byte(c.example), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("example = %s, want %s", ep.ResponseExample, c.example)
+			}
+		})
+	}
+}
+
+func TestFieldPathSpellings(t *testing.T) {
+	cases := map[string]string{
+		"data.items[].name": "data.items[].name|data.items.items[].name",
+		"data.itemsCount":   "data.itemsCount",
+		"data.list[]":       "data.list[]|data.list.items[]|data.list.items",
+		"[].id":             "[].id",
+	}
+	for path, want := range cases {
+		if got := strings.Join(fieldPathSpellings(path), "|"); got != want {
+			t.Errorf("fieldPathSpellings(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestApplyCustomizeParams(t *testing.T) {
+	rules := CustomizeReqAndRes{
+		Headers: map[string]string{"X-Trace": "string#optional#legacy", "X-Token": "string#required#token"},
+		Params: []ParamSpec{
+			{Name: "x-trace", Type: "string", Required: true, Description: "structured"},
+			{Name: "page", In: "query", Type: "integer", Example: 1, Enum: []string{"1", "2"}},
+			{Name: "sid", In: "cookie", Type: "string"},
+			{Name: "userId", In: "path", Type: "string"},
+		},
+	}
+	ps := applyCustomizeParams(endpointParams{Path: []ParamInfo{{Name: "userId", In: "path", Required: true, Type: "integer"}}}, rules)
+	describe := func(list []ParamInfo) string {
+		var res []string
+		for _, p := range list {
+			res = append(res, p.Name+":"+p.Type+":"+p.Desc)
+		}
+		return strings.Join(res, ",")
+	}
+	if got, want := describe(ps.Headers), "x-trace:string:structured,X-Token:string:token"; got != want {
+		t.Errorf("headers = %s, want %s", got, want)
+	}
+	if got, want := describe(ps.Path), "userId:integer:"; got != want {
+		t.Errorf("path = %s, want declared parameter kept", got)
+	}
+	if len(ps.Query) != 1 || ps.Query[0].Example != "1" || !reflect.DeepEqual(ps.Query[0].Enum, []string{"1", "2"}) {
+		t.Errorf("query = %+v, want page with example and enum", ps.Query)
+	}
+	if got, want := describe(ps.Cookie), "sid:string:"; got != want {
+		t.Errorf("cookie = %s, want %s", got, want)
+	}
+}
+
+func TestAssignAnchors(t *testing.T) {
+	raw := `{"paths":{
+		"/a/{id}":{"get":{"operationId":"getA","tags":["A/x"]}},
+		"/a/id":{"get":{"tags":["A/x","()"]}},
+		"/b":{"post":{"tags":["[]"]}}}}`
+	j := gjson.New(raw)
+	cfg := RenderConfig{GroupBy: GroupByTags}
+	ops := collectOperations(j, raw, cfg, loadTagCatalog(j, "/"))
+	reg := newAnchorRegistry()
+	anchors := cfg.assignAnchors(ops, false, reg, cfg.locale())
+	var got []string
+	for _, op := range ops {
+		got = append(got, op.Anchor)
+	}
+	if want := []string{"get-a-id", "get-a-id-2", "get-a-id-2-2", "post-b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("anchors = %v, want %v", got, want)
+	}
+	if anchors.group("()") == anchors.group("[]") {
+		t.Errorf("punctuation-only groups share anchor %s", anchors.group("()"))
+	}
+	if r := reg.redirects(); r["group-"] == "" {
+		t.Errorf("redirects = %v, want legacy group- mapped", r)
+	}
+
+	cfg.AnchorOperationID = true
+	ops = collectOperations(j, raw, cfg, loadTagCatalog(j, "/"))
+	reg = newAnchorRegistry()
+	cfg.assignAnchors(ops, false, reg, cfg.locale())
+	if ops[0].Anchor != "getA" || ops[1].Anchor != "get-a-id" {
+		t.Errorf("operationId anchors = %s, %s", ops[0].Anchor, ops[1].Anchor)
+	}
+	if _, ok := reg.redirects()["get-a-id"]; ok {
+		t.Errorf("redirect for get-a-id must be dropped while the id is in use")
+	}
+}
+
+func TestSectionAnchorsDoNotCollide(t *testing.T) {
+	raw := `{"paths":{
+		"/a/url":{"get":{"summary":"url","responses":{"200":{"content":{"application/json":{"schema":{"type":"object","properties":{"id":{"type":"string"}}}}}}}}},
+		"/a":{"get":{"summary":"a","parameters":[{"name":"q","in":"query"}],"responses":{"200":{"content":{"application/json":{"schema":{"type":"object","properties":{"id":{"type":"string"}}}}}}}}},
+		"/servers":{"get":{"operationId":"servers"}},
+		"/x":{"get":{"operationId":"get-a-req"}}}}`
+	for _, byOpID := range []bool{false, true} {
+		cfg := RenderConfig{AnchorOperationID: byOpID}
+		page := GenerateHTMLWithConfig(gjson.New(raw), raw, cfg)
+		seen := map[string]int{}
+		for _, m := range regexp.MustCompile(` id="([^"]*)"`).FindAllStringSubmatch(page, -1) {
+			seen[m[1]]++
+		}
+		for id, n := range seen {
+			if n > 1 {
+				t.Errorf("AnchorOperationID=%v: id %q appears %d times", byOpID, id, n)
+			}
+		}
+		// GET /a 的小节锚点保持不变，与之同名的 GET /a/url 追加后缀
+		anchors := OperationAnchors(gjson.New(raw), raw, cfg)
+		if anchors["GET /a"] != "get-a" || anchors["GET /a/url"] != "get-a-url-2" || seen["get-a-url"] != 1 || seen["get-a-url-2-url"] != 1 {
+			t.Errorf("AnchorOperationID=%v: anchors = %v", byOpID, anchors)
+		}
+	}
+	// 与页面固定 id 或其他接口小节锚点相同的 operationId 追加后缀
+	anchors := OperationAnchors(gjson.New(raw), raw, RenderConfig{AnchorOperationID: true})
+	if anchors["GET /servers"] != "servers-2" || anchors["GET /x"] != "get-a-req-2" {
+		t.Errorf("operationId anchors = %v", anchors)
+	}
+}
+
+func TestParamLocations(t *testing.T) {
+	raw := `{"paths":{"/a/{id}":{"get":{"parameters":[
+		{"name":"id","in":"Path","required":true},
+		{"name":"page","in":"QUERY"},
+		{"name":"sid","in":" cookie "},
+		{"name":"body","in":"body"}]}}}}`
+	j := gjson.New(raw)
+	pj := j.GetJsonMap("paths")["/a/{id}"]
+	rules := CustomizeReqAndRes{Params: []ParamSpec{
+		{Name: "size", In: "Query"},
+		{Name: "PAGE", In: "query"},
+		{Name: "X-Trace", In: "HEADER"},
+		{Name: "token", In: "Cookie"},
+		{Name: "payload", In: "body"},
+		{Name: "form", In: "formData"},
+	}}
+	ep := buildEndpoint(j, "/a/{id}", "get", pj, pj.GetJson("get"), RenderConfig{Customize: map[string]CustomizeReqAndRes{"/a/{id}": rules}})
+	describe := func(list []ParamInfo) string {
+		var res []string
+		for _, p := range list {
+			res = append(res, p.In+":"+p.Name)
+		}
+		return strings.Join(res, ",")
+	}
+	got := map[string]string{
+		"headers": describe(ep.Headers),
+		"path":    describe(ep.PathParams),
+		"query":   describe(ep.QueryParams),
+		"cookie":  describe(ep.CookieParams),
+	}
+	want := map[string]string{
+		// 位置不合法的参数（规范中的 body 与注入的 body/formData）不出现在任何位置
+		"headers": "header:X-Trace",
+		"path":    "path:id",
+		// query 参数名区分大小写，PAGE 与 page 为不同参数
+		"query":  "query:page,query:size,query:PAGE",
+		"cookie": "cookie:sid,cookie:token",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("params = %v, want %v", got, want)
+	}
+	for _, in := range []string{"Query", " path ", "HEADER", "cookie"} {
+		if !IsParamIn(in) {
+			t.Errorf("IsParamIn(%q) = false", in)
+		}
+	}
+	for _, in := range []string{"", "body", "formData"} {
+		if IsParamIn(in) {
+			t.Errorf("IsParamIn(%q) = true", in)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// Deprecation 废弃标记，嵌入 EndpointData、FieldInfo 与 ParamInfo。
+// - Deprecated: deprecated: true，或声明了 x-deprecated-since
+// - DeprecatedSince/Sunset: 扩展字段 x-deprecated-since（起始版本）与 x-sunset（计划下线日期）
+type Deprecation struct {
+	Deprecated      bool
+	DeprecatedSince string
+	Sunset          string
+}
+
+// deprecationOf 读取 schema、参数或操作上的废弃标记；$ref 时合并引用组件上的标记（引用处优先）。
+func deprecationOf(j *gjson.Json, s *gjson.Json) Deprecation {
+	return deprecationOfRef(j, s, map[string]bool{})
+}
+
+// deprecationOfRef 同 deprecationOf；seen 记录已展开的 $ref，防止循环引用无限递归。
+func deprecationOfRef(j *gjson.Json, s *gjson.Json, seen map[string]bool) Deprecation {
+	var d Deprecation
+	if s == nil || s.IsNil() {
+		return d
+	}
+	if r := s.Get("$ref").String(); r != "" && !seen[r] {
+		seen[r] = true
+		if sub := getRefJson(j, r); sub != nil {
+			d = deprecationOfRef(j, sub, seen)
+		}
+	}
+	if s.Get("deprecated").Bool() {
+		d.Deprecated = true
+	}
+	if v := s.Get("x-deprecated-since").String(); v != "" {
+		d.DeprecatedSince, d.Deprecated = v, true
+	}
+	if v := s.Get("x-sunset").String(); v != "" {
+		d.Sunset = v
+	}
+	return d
+}
+
+// deprecationText 废弃标记的展示文本，如 “已废弃 (起始版本 2.3, 下线日期 2026-01-01)”；未废弃时为空串。
+func deprecationText(d Deprecation, loc Locale) string {
+	if !d.Deprecated {
+		return ""
+	}
+	var parts []string
+	if d.DeprecatedSince != "" {
+		parts = append(parts, loc.T("deprecated_since")+" "+d.DeprecatedSince)
+	}
+	if d.Sunset != "" {
+		parts = append(parts, loc.T("sunset")+" "+d.Sunset)
+	}
+	if len(parts) == 0 {
+		return loc.T("deprecated")
+	}
+	return loc.T("deprecated") + " (" + strings.Join(parts, ", ") + ")"
+}
+
+// nameCellHTML 表格名称单元格：废弃项加删除线并附废弃标记。
+func nameCellHTML(name string, d Deprecation, loc Locale) string {
+	if !d.Deprecated {
+		return htmlEscape(name)
+	}
+	return "<s>" + htmlEscape(name) + "</s> <span class=\"deprecated-badge\">" + htmlEscape(deprecationText(d, loc)) + "</span>"
+}
+
+// nameCellMarkdown 表格名称单元格的 Markdown 形式。
+func nameCellMarkdown(name string, d Deprecation, loc Locale) string {
+	if !d.Deprecated {
+		return name
+	}
+	return "~~" + name + "~~ " + deprecationText(d, loc)
+}
+
+// withoutDeprecatedParams 移除已废弃的参数。
+func withoutDeprecatedParams(list []ParamInfo) []ParamInfo {
+	res := list[:0:0]
+	for _, p := range list {
+		if !p.Deprecated {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// hidesOperation 判断接口是否不输出：命中的定制规则设置了 Hide，或因 HideDeprecated 隐藏已废弃接口。
+func (cfg RenderConfig) hidesOperation(j *gjson.Json, op *gjson.Json, rules CustomizeReqAndRes) bool {
+	return rules.Hide || cfg.HideDeprecated && deprecationOf(j, op).Deprecated
+}
+
+// fieldView 结合请求/返回视图与 HideDeprecated 得到字段视图。
+func (cfg RenderConfig) fieldView(view schemaView) schemaView {
+	if cfg.HideDeprecated {
+		return view | viewNoDeprecated
+	}
+	return view
+}
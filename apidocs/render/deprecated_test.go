@@ -0,0 +1,105 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// deprecatedSpec /old 为废弃接口（带起始版本与下线日期）；/new 含废弃的参数与字段（字段经 $ref 组件标记）。
+const deprecatedSpec = `{"info":{"title":"t"},"paths":{
+	"/old":{"get":{"summary":"旧接口","x-deprecated-since":"2.3","x-sunset":"2026-01-01","tags":["A"],"responses":{"200":{"description":"ok"}}}},
+	"/new":{"get":{"summary":"新接口","tags":["A"],
+		"parameters":[{"name":"page","in":"query","schema":{"type":"integer"}},{"name":"legacyCursor","in":"query","deprecated":true,"schema":{"type":"integer"}}],
+		"responses":{"200":{"content":{"application/json":{"schema":{"type":"object","properties":{
+			"name":{"type":"string"},
+			"nick":{"$ref":"#/components/schemas/Nick"}}}}}}}}}},
+	"components":{"schemas":{"Nick":{"type":"string","deprecated":true}}}}`
+
+func TestDeprecationOf(t *testing.T) {
+	j := gjson.New(deprecatedSpec)
+	cases := []struct {
+		schema string
+		want   Deprecation
+	}{
+		{`{}`, Deprecation{}},
+		{`{"deprecated":true}`, Deprecation{Deprecated: true}},
+		{`{"x-deprecated-since":"1.0"}`, Deprecation{Deprecated: true, DeprecatedSince: "1.0"}},
+		// 仅有 x-sunset 不视为废弃
+		{`{"x-sunset":"2030-01-01"}`, Deprecation{Sunset: "2030-01-01"}},
+		// $ref 合并组件上的标记，引用处的值优先
+		{`{"$ref":"#/components/schemas/Nick","x-sunset":"2027-01-01"}`, Deprecation{Deprecated: true, Sunset: "2027-01-01"}},
+	}
+	for _, c := range cases {
+		if got := deprecationOf(j, gjson.New(c.schema)); got != c.want {
+			t.Errorf("deprecationOf(%s) = %+v, want %+v", c.schema, got, c.want)
+		}
+	}
+	// 循环引用：合并链上各组件的标记后停止
+	cyclic := gjson.New(`{"components":{"schemas":{
+		"A":{"$ref":"#/components/schemas/B","deprecated":true},
+		"B":{"$ref":"#/components/schemas/A","x-sunset":"2030-01-01"}}}}`)
+	if got := deprecationOf(cyclic, gjson.New(`{"$ref":"#/components/schemas/A"}`)); got != (Deprecation{Deprecated: true, Sunset: "2030-01-01"}) {
+		t.Errorf("cyclic deprecationOf = %+v", got)
+	}
+	if got := deprecationOf(j, nil); got != (Deprecation{}) {
+		t.Errorf("deprecationOf(nil) = %+v", got)
+	}
+}
+
+func TestDeprecationText(t *testing.T) {
+	zh, en := RenderConfig{}.locale(), RenderConfig{Lang: LangEn}.locale()
+	cases := []struct {
+		d      Deprecation
+		loc    Locale
+		want   string
+		wantMD string
+	}{
+		{Deprecation{}, zh, "", "name"},
+		{Deprecation{Deprecated: true}, zh, "已废弃", "~~name~~ 已废弃"},
+		{Deprecation{Deprecated: true, DeprecatedSince: "2.3", Sunset: "2026-01-01"}, en, "Deprecated (since 2.3, sunset 2026-01-01)", "~~name~~ Deprecated (since 2.3, sunset 2026-01-01)"},
+	}
+	for _, c := range cases {
+		if got := deprecationText(c.d, c.loc); got != c.want {
+			t.Errorf("deprecationText(%+v) = %q, want %q", c.d, got, c.want)
+		}
+		if got := nameCellMarkdown("name", c.d, c.loc); got != c.wantMD {
+			t.Errorf("nameCellMarkdown(%+v) = %q, want %q", c.d, got, c.wantMD)
+		}
+	}
+	if got := nameCellHTML("<a>", Deprecation{Deprecated: true}, zh); got != `<s>&lt;a&gt;</s> <span class="deprecated-badge">已废弃</span>` {
+		t.Errorf("nameCellHTML = %s", got)
+	}
+}
+
+func TestDeprecatedOutput(t *testing.T) {
+	j := gjson.New(deprecatedSpec)
+	page := GenerateHTMLWithConfig(j, deprecatedSpec, RenderConfig{})
+	for _, want := range []string{
+		`class="item-link deprecated" href="#get-old"`,
+		`<s>旧接口</s> <span class="deprecated-badge">已废弃 (起始版本 2.3, 下线日期 2026-01-01)</span>`,
+		`<s>legacyCursor</s>`,
+		`<s>nick</s>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("html missing %q", want)
+		}
+	}
+	md := GenerateMarkdownWithConfig(j, deprecatedSpec, RenderConfig{})
+	if !strings.Contains(md, "~~旧接口~~ 已废弃 (起始版本 2.3, 下线日期 2026-01-01)") || !strings.Contains(md, "~~legacyCursor~~") {
+		t.Errorf("markdown missing deprecation marks:\n%s", md)
+	}
+
+	cfg := RenderConfig{HideDeprecated: true}
+	page = GenerateHTMLWithConfig(j, deprecatedSpec, cfg)
+	md = GenerateMarkdownWithConfig(j, deprecatedSpec, cfg)
+	for name, out := range map[string]string{"html": page, "markdown": md} {
+		if strings.Contains(out, "旧接口") || strings.Contains(out, "legacyCursor") || strings.Contains(out, "nick") {
+			t.Errorf("%s with HideDeprecated still shows deprecated items", name)
+		}
+		if !strings.Contains(out, "新接口") || !strings.Contains(out, "page") {
+			t.Errorf("%s with HideDeprecated lost visible items", name)
+		}
+	}
+}
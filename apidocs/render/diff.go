@@ -0,0 +1,462 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/source"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// 变更类型与范围取值，用于 DiffChange.Kind 与 DiffChange.Scope。
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+
+	DiffScopeEndpoint  = "endpoint"
+	DiffScopeParameter = "parameter"
+	DiffScopeRequest   = "request"
+	DiffScopeResponse  = "response"
+)
+
+// DiffChange 描述两份规范之间的一处差异。
+// - Field: 参数为 "in:name"（例如 query:page），请求/返回字段为扁平路径（例如 data.items[].id），返回状态码为 "状态码"
+// - Breaking: 是否为破坏性变更（删除接口/返回字段、新增必填请求字段、类型变化、请求枚举收窄等）
+type DiffChange struct {
+	Kind     string `json:"kind"`
+	Scope    string `json:"scope"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	Status   string `json:"status,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Breaking bool   `json:"breaking"`
+	Message  string `json:"message"`
+}
+
+// DiffReport 两份规范的差异报告，Changes 按目标规范的 paths 顺序排列（被删除的接口排在最后）。
+type DiffReport struct {
+	Base        string       `json:"base"`
+	Target      string       `json:"target"`
+	Breaking    int          `json:"breaking"`
+	NonBreaking int          `json:"nonBreaking"`
+	Changes     []DiffChange `json:"changes"`
+	// loc 生成变更说明所用的语言包
+	loc Locale
+}
+
+// HasBreaking 报告是否包含破坏性变更，便于 CI 卡点。
+func (r DiffReport) HasBreaking() bool { return r.Breaking > 0 }
+
+// DiffSpecs 比较 base（旧）与 target（新）两份规范，返回接口、参数、请求字段与返回字段的增删改。
+// 说明：
+// - 接口以 METHOD+path 为键；请求/返回字段使用与参数表一致的扁平路径（FieldInfo.Path）；
+// - 返回字段按状态码逐一比较，仅比较双方都声明的状态码，状态码本身的增删单独记录；
+// - 破坏性判定：删除接口、删除返回字段或状态码、新增必填参数/请求字段、可选变必填、类型变化、请求枚举收窄、返回枚举扩大；
+// - 变更说明（Message）使用默认语言（zh-CN），需要其他语言时使用 DiffSpecsWithConfig。
+func DiffSpecs(base *gjson.Json, baseRaw string, target *gjson.Json, targetRaw string) DiffReport {
+	return DiffSpecsWithConfig(base, baseRaw, target, targetRaw, RenderConfig{})
+}
+
+// DiffSpecsWithConfig 同 DiffSpecs，变更说明按 cfg.Lang 生成。
+func DiffSpecsWithConfig(base *gjson.Json, baseRaw string, target *gjson.Json, targetRaw string, cfg RenderConfig) DiffReport {
+	rep := DiffReport{Changes: []DiffChange{}, loc: cfg.locale()}
+	baseOps := diffOperations(base, baseRaw)
+	targetOps := diffOperations(target, targetRaw)
+	seen := make(map[string]bool, len(targetOps))
+	for _, op := range targetOps {
+		key := op.method + " " + op.path
+		seen[key] = true
+		old := findDiffOp(baseOps, key)
+		if old == nil {
+			rep.add(DiffChange{Kind: DiffAdded, Scope: DiffScopeEndpoint, Method: op.method, Path: op.path, Message: rep.loc.T("d_op_added")})
+			continue
+		}
+		diffParams(&rep, base, target, *old, op)
+		diffRequest(&rep, base, target, *old, op)
+		diffResponses(&rep, base, target, *old, op)
+	}
+	for _, op := range baseOps {
+		if !seen[op.method+" "+op.path] {
+			rep.add(DiffChange{Kind: DiffRemoved, Scope: DiffScopeEndpoint, Method: op.method, Path: op.path, Breaking: true, Message: rep.loc.T("d_op_removed")})
+		}
+	}
+	return rep
+}
+
+// add 追加一条变更并更新计数。
+func (r *DiffReport) add(c DiffChange) {
+	c.Method = strings.ToUpper(c.Method)
+	r.Changes = append(r.Changes, c)
+	if c.Breaking {
+		r.Breaking++
+	} else {
+		r.NonBreaking++
+	}
+}
+
+// diffOp 参与比较的单个接口。
+type diffOp struct {
+	path     string
+	method   string
+	pathItem *gjson.Json
+	op       *gjson.Json
+}
+
+// diffOperations 按 paths 原始顺序列出规范中的所有接口。
+func diffOperations(j *gjson.Json, raw string) []diffOp {
+	if j == nil {
+		return nil
+	}
+	paths := j.GetJsonMap("paths")
+	keys := source.OrderedPathsFromContent(raw)
+	if len(keys) == 0 {
+		for k := range paths {
+			keys = append(keys, k)
+		}
+		sortStrings(keys)
+	}
+	res := make([]diffOp, 0, len(keys))
+	for _, p := range keys {
+		pj := paths[p]
+		if pj == nil {
+			continue
+		}
+		for _, m := range presentMethods(pj) {
+			res = append(res, diffOp{path: p, method: m, pathItem: pj, op: pj.GetJson(m)})
+		}
+	}
+	return res
+}
+
+func findDiffOp(ops []diffOp, key string) *diffOp {
+	for i := range ops {
+		if ops[i].method+" "+ops[i].path == key {
+			return &ops[i]
+		}
+	}
+	return nil
+}
+
+// diffParam 参数比较所需的最小信息。
+type diffParam struct {
+	required bool
+	typ      string
+	enum     []string
+}
+
+// diffParameters 汇总 path+op 两层参数，键为 "in:name"，并解析 $ref。
+func diffParameters(j *gjson.Json, o diffOp) (map[string]diffParam, []string) {
+	res := make(map[string]diffParam)
+	order := make([]string, 0, 8)
+	arr := append(o.pathItem.Get("parameters").Array(), o.op.Get("parameters").Array()...)
+	for _, v := range arr {
+		pj := gjson.New(v)
+		if ref := pj.Get("$ref").String(); ref != "" {
+			if pj = getRefJson(j, ref); pj == nil {
+				continue
+			}
+		}
+		key := pj.Get("in").String() + ":" + pj.Get("name").String()
+		if _, ok := res[key]; !ok {
+			order = append(order, key)
+		}
+		s := pj.GetJson("schema")
+		res[key] = diffParam{required: pj.Get("required").Bool(), typ: paramSchemaType(j, s), enum: s.Get("enum").Strings()}
+	}
+	return res, order
+}
+
+func diffParams(rep *DiffReport, base, target *gjson.Json, old, cur diffOp) {
+	oldPs, oldOrder := diffParameters(base, old)
+	curPs, curOrder := diffParameters(target, cur)
+	for _, k := range curOrder {
+		np := curPs[k]
+		op, ok := oldPs[k]
+		if !ok {
+			msg := rep.loc.T("d_param_opt")
+			if np.required {
+				msg = rep.loc.T("d_param_req")
+			}
+			rep.add(DiffChange{Kind: DiffAdded, Scope: DiffScopeParameter, Method: cur.method, Path: cur.path, Field: k, Breaking: np.required, Message: msg})
+			continue
+		}
+		diffLeaf(rep, DiffScopeParameter, cur, "", k, true,
+			FieldInfo{Required: op.required, Type: op.typ, Constraints: Constraints{Enum: op.enum}},
+			FieldInfo{Required: np.required, Type: np.typ, Constraints: Constraints{Enum: np.enum}})
+	}
+	for _, k := range oldOrder {
+		if _, ok := curPs[k]; !ok {
+			rep.add(DiffChange{Kind: DiffRemoved, Scope: DiffScopeParameter, Method: cur.method, Path: cur.path, Field: k, Message: rep.loc.T("d_param_removed")})
+		}
+	}
+}
+
+func diffRequest(rep *DiffReport, base, target *gjson.Json, old, cur diffOp) {
+	oldSchema, _, _ := getRequestSchema(base, old.op)
+	curSchema, _, _ := getRequestSchema(target, cur.op)
+	if oldSchema == nil && curSchema == nil {
+		return
+	}
+	if oldSchema == nil {
+		required := cur.op.Get("requestBody.required").Bool()
+		rep.add(DiffChange{Kind: DiffAdded, Scope: DiffScopeRequest, Method: cur.method, Path: cur.path, Breaking: required, Message: rep.loc.T("d_body_added")})
+		return
+	}
+	if curSchema == nil {
+		rep.add(DiffChange{Kind: DiffRemoved, Scope: DiffScopeRequest, Method: cur.method, Path: cur.path, Message: rep.loc.T("d_body_removed")})
+		return
+	}
+	diffFields(rep, DiffScopeRequest, cur, "", flattenSchemaFieldsFromJson(base, oldSchema, "", viewRequest), flattenSchemaFieldsFromJson(target, curSchema, "", viewRequest))
+}
+
+func diffResponses(rep *DiffReport, base, target *gjson.Json, old, cur diffOp) {
+	oldResps := old.op.GetJsonMap("responses")
+	curResps := cur.op.GetJsonMap("responses")
+	codes := make([]string, 0, len(curResps))
+	for c := range curResps {
+		codes = append(codes, c)
+	}
+	sortStrings(codes)
+	for _, c := range codes {
+		if _, ok := oldResps[c]; !ok {
+			rep.add(DiffChange{Kind: DiffAdded, Scope: DiffScopeResponse, Method: cur.method, Path: cur.path, Status: c, Message: rep.loc.T("d_status_added")})
+			continue
+		}
+		oldSchema := responseSchemaFor(base, oldResps[c])
+		curSchema := responseSchemaFor(target, curResps[c])
+		if oldSchema == nil || curSchema == nil {
+			continue
+		}
+		diffFields(rep, DiffScopeResponse, cur, c, flattenSchemaFieldsFromJson(base, oldSchema, "", viewResponse), flattenSchemaFieldsFromJson(target, curSchema, "", viewResponse))
+	}
+	oldCodes := make([]string, 0, len(oldResps))
+	for c := range oldResps {
+		oldCodes = append(oldCodes, c)
+	}
+	sortStrings(oldCodes)
+	for _, c := range oldCodes {
+		if _, ok := curResps[c]; !ok {
+			rep.add(DiffChange{Kind: DiffRemoved, Scope: DiffScopeResponse, Method: cur.method, Path: cur.path, Status: c, Breaking: true, Message: rep.loc.T("d_status_removed")})
+		}
+	}
+}
+
+// responseSchemaFor 解析单个响应对象（兼容 $ref）中首选媒体类型的 schema。
+func responseSchemaFor(j *gjson.Json, resp *gjson.Json) *gjson.Json {
+	if resp == nil {
+		return nil
+	}
+	if r := resp.Get("$ref").String(); r != "" {
+		if resp = getRefJson(j, r); resp == nil {
+			return nil
+		}
+	}
+	_, media := pickMediaType(resp.GetJsonMap("content"))
+	if media == nil || media.Get("schema").IsNil() {
+		return nil
+	}
+	if r := media.Get("schema.$ref").String(); r != "" {
+		return getRefJson(j, r)
+	}
+	return media.GetJson("schema")
+}
+
+// diffFields 比较两组扁平字段；request 侧新增必填为破坏性，response 侧删除为破坏性。
+func diffFields(rep *DiffReport, scope string, cur diffOp, status string, oldFields, curFields []FieldInfo) {
+	oldMap := make(map[string]FieldInfo, len(oldFields))
+	for _, f := range oldFields {
+		oldMap[f.Path] = f
+	}
+	curMap := make(map[string]FieldInfo, len(curFields))
+	for _, f := range curFields {
+		curMap[f.Path] = f
+		of, ok := oldMap[f.Path]
+		if !ok {
+			breaking := scope == DiffScopeRequest && f.Required
+			msg := rep.loc.T("d_field_added")
+			if breaking {
+				msg = rep.loc.T("d_field_req")
+			}
+			rep.add(DiffChange{Kind: DiffAdded, Scope: scope, Method: cur.method, Path: cur.path, Status: status, Field: f.Path, Breaking: breaking, Message: msg})
+			continue
+		}
+		diffLeaf(rep, scope, cur, status, f.Path, scope == DiffScopeRequest, of, f)
+	}
+	for _, f := range oldFields {
+		if _, ok := curMap[f.Path]; !ok {
+			rep.add(DiffChange{Kind: DiffRemoved, Scope: scope, Method: cur.method, Path: cur.path, Status: status, Field: f.Path, Breaking: scope == DiffScopeResponse, Message: rep.loc.T("d_field_removed")})
+		}
+	}
+}
+
+// diffLeaf 比较同一字段/参数的类型、必填与枚举；input 为 true 表示由调用方传入（参数、请求体）。
+func diffLeaf(rep *DiffReport, scope string, cur diffOp, status, field string, input bool, of, nf FieldInfo) {
+	base := DiffChange{Kind: DiffChanged, Scope: scope, Method: cur.method, Path: cur.path, Status: status, Field: field}
+	if sanitizeType(of.Type) != sanitizeType(nf.Type) {
+		c := base
+		c.Breaking = true
+		c.Message = rep.loc.Tf("d_type", sanitizeType(of.Type), sanitizeType(nf.Type))
+		rep.add(c)
+	}
+	if input && of.Required != nf.Required {
+		c := base
+		c.Breaking = nf.Required
+		if nf.Required {
+			c.Message = rep.loc.T("d_now_required")
+		} else {
+			c.Message = rep.loc.T("d_now_optional")
+		}
+		rep.add(c)
+	}
+	removed, added := enumDelta(of.Enum, nf.Enum)
+	if len(removed) == 0 && len(added) == 0 {
+		return
+	}
+	c := base
+	var parts []string
+	if len(removed) > 0 {
+		parts = append(parts, rep.loc.Tf("d_enum_removed", strings.Join(removed, ", ")))
+	}
+	if len(added) > 0 {
+		parts = append(parts, rep.loc.Tf("d_enum_added", strings.Join(added, ", ")))
+	}
+	c.Message = strings.Join(parts, rep.loc.T("msg_sep"))
+	if input {
+		c.Breaking = len(removed) > 0 && len(nf.Enum) > 0
+	} else {
+		c.Breaking = len(added) > 0 && len(of.Enum) > 0
+	}
+	rep.add(c)
+}
+
+// enumDelta 返回枚举值的删除与新增集合（保持原有顺序）。
+func enumDelta(old, cur []string) (removed, added []string) {
+	om := make(map[string]bool, len(old))
+	for _, v := range old {
+		om[v] = true
+	}
+	cm := make(map[string]bool, len(cur))
+	for _, v := range cur {
+		cm[v] = true
+		if !om[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range old {
+		if !cm[v] {
+			removed = append(removed, v)
+		}
+	}
+	return
+}
+
+// diffKindLabel 变更类型的展示文本。
+func diffKindLabel(kind string, loc Locale) string {
+	switch kind {
+	case DiffAdded:
+		return loc.T("diff_added")
+	case DiffRemoved:
+		return loc.T("diff_removed")
+	}
+	return loc.T("diff_changed")
+}
+
+// diffTarget 拼接变更所在位置的展示文本（状态码 + 字段）。
+func diffTarget(c DiffChange) string {
+	var parts []string
+	if c.Scope != DiffScopeEndpoint {
+		parts = append(parts, c.Scope)
+	}
+	if c.Status != "" {
+		parts = append(parts, c.Status)
+	}
+	if c.Field != "" {
+		parts = append(parts, c.Field)
+	}
+	return strings.Join(parts, " ")
+}
+
+// RenderDiffMarkdown 将差异报告渲染为 Markdown 片段（二级标题 + 破坏性/非破坏性两张表），可直接拼接到导出文档。
+// 标题与表头按 cfg.Lang 取值；变更说明（Message）在生成报告时已按语言生成（见 DiffSpecsWithConfig）。
+func RenderDiffMarkdown(r DiffReport, cfg RenderConfig) string {
+	loc := cfg.locale()
+	var b strings.Builder
+	b.WriteString("## " + loc.T("diff_title") + "\n\n")
+	if r.Base != "" || r.Target != "" {
+		b.WriteString("- " + loc.T("diff_base") + ": `" + r.Base + "`\n- " + loc.T("diff_target") + ": `" + r.Target + "`\n")
+	}
+	b.WriteString(fmt.Sprintf("- %s: %d\n- %s: %d\n\n", loc.T("diff_breaking"), r.Breaking, loc.T("diff_nonbreaking"), r.NonBreaking))
+	for _, breaking := range []bool{true, false} {
+		title := "### " + loc.T("diff_breaking") + "\n\n"
+		if !breaking {
+			title = "### " + loc.T("diff_nonbreaking") + "\n\n"
+		}
+		b.WriteString(title)
+		b.WriteString("| " + loc.T("col_kind") + " | " + loc.T("col_endpoint") + " | " + loc.T("col_location") + " | " + loc.T("col_desc") + " |\n|---|---|---|---|\n")
+		n := 0
+		for _, c := range r.Changes {
+			if c.Breaking != breaking {
+				continue
+			}
+			n++
+			b.WriteString(fmt.Sprintf("| %s | `%s %s` | %s | %s |\n", diffKindLabel(c.Kind, loc), c.Method, c.Path, diffTarget(c), c.Message))
+		}
+		if n == 0 {
+			b.WriteString("| " + loc.T("none") + " |  |  |  |\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderDiffTableHTML 渲染一组变更为 HTML 表格；接口列链接到文档页对应锚点（不在文档页中的接口不链接）。
+func renderDiffTableHTML(changes []DiffChange, breaking bool, docsRoute string, anchors map[string]string, loc Locale) string {
+	var b strings.Builder
+	b.WriteString("<table><thead><tr><th>" + loc.T("col_kind") + "</th><th>" + loc.T("col_endpoint") + "</th><th>" + loc.T("col_location") + "</th><th>" + loc.T("col_desc") + "</th></tr></thead><tbody>")
+	n := 0
+	for _, c := range changes {
+		if c.Breaking != breaking {
+			continue
+		}
+		n++
+		ep := htmlEscape(c.Method + " " + c.Path)
+		if id, ok := anchors[c.Method+" "+c.Path]; ok && (c.Kind != DiffRemoved || c.Scope != DiffScopeEndpoint) {
+			ep = "<a href=\"" + htmlEscape(docsRoute) + "#" + htmlEscape(id) + "\">" + ep + "</a>"
+		}
+		b.WriteString("<tr><td>" + diffKindLabel(c.Kind, loc) + "</td><td>" + ep + "</td><td>" + htmlEscape(diffTarget(c)) + "</td><td>" + htmlEscape(c.Message) + "</td></tr>")
+	}
+	if n == 0 {
+		b.WriteString("<tr><td colspan=4>" + loc.T("none") + "</td></tr>")
+	}
+	b.WriteString("</tbody></table>")
+	return b.String()
+}
+
+// GenerateDiffHTML 将差异报告渲染为完整 HTML 页面（复用文档页的布局与样式模板）。
+// docsRoute 为文档页路由，anchors 为文档页中各接口的锚点（见 OperationAnchors），用于将接口链接到对应锚点。
+func GenerateDiffHTML(r DiffReport, docsRoute string, anchors map[string]string, cfg RenderConfig) string {
+	loc := cfg.locale()
+	var main strings.Builder
+	main.WriteString("<h1>" + loc.T("diff_title") + "</h1>")
+	main.WriteString("<ul><li>" + loc.T("diff_base") + ": <code>" + htmlEscape(r.Base) + "</code></li><li>" + loc.T("diff_target") + ": <code>" + htmlEscape(r.Target) + "</code></li></ul>")
+	main.WriteString("<h2 id=\"diff-breaking\">" + loc.T("diff_breaking") + "</h2>")
+	main.WriteString(renderDiffTableHTML(r.Changes, true, docsRoute, anchors, loc))
+	main.WriteString("<h2 id=\"diff-non-breaking\">" + loc.T("diff_nonbreaking") + "</h2>")
+	main.WriteString(renderDiffTableHTML(r.Changes, false, docsRoute, anchors, loc))
+	t, err := buildLayoutTemplate(cfg)
+	if err != nil {
+		return "<div class=\"layout\"><aside></aside><main>" + main.String() + "</main></div>"
+	}
+	// 侧边导航：破坏性/非破坏性两组，条目为变更涉及的接口
+	var nav strings.Builder
+	_ = t.ExecuteTemplate(&nav, "nav", NavData{Locale: loc, Groups: []*NavGroupVM{
+		{Name: fmt.Sprintf("%s (%d)", loc.T("diff_breaking"), r.Breaking), Id: "diff-breaking"},
+		{Name: fmt.Sprintf("%s (%d)", loc.T("diff_nonbreaking"), r.NonBreaking), Id: "diff-non-breaking"},
+	}})
+	var out strings.Builder
+	_ = t.ExecuteTemplate(&out, "layout", pageData{Locale: loc, Title: loc.T("diff_title"), NavHTML: template.HTML(nav.String()), MainHTML: template.HTML(main.String())})
+	return out.String()
+}
@@ -0,0 +1,207 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// diffOpSpec 以单个 GET /items 接口构造规范；params、request 与 response 为对应位置的 JSON 片段。
+func diffOpSpec(params, request, response string) string {
+	op := `"parameters":[` + params + `],"responses":{"200":{"content":{"application/json":{"schema":` + response + `}}}}`
+	if request != "" {
+		op += `,"requestBody":{"content":{"application/json":{"schema":` + request + `}}}`
+	}
+	return `{"paths":{"/items":{"get":{` + op + `}}}}`
+}
+
+func TestDiffSpecsClassification(t *testing.T) {
+	obj := func(props string, required ...string) string {
+		return `{"type":"object","required":["` + strings.Join(required, `","`) + `"],"properties":{` + props + `}}`
+	}
+	idName := obj(`"id":{"type":"integer"},"name":{"type":"string"}`)
+	cases := []struct {
+		name         string
+		base, target string
+		// want 期望的变更：kind scope field breaking（按报告顺序）
+		want []string
+	}{
+		{
+			name:   "removed operation",
+			base:   `{"paths":{"/a":{"get":{}},"/b":{"get":{}}}}`,
+			target: `{"paths":{"/a":{"get":{}}}}`,
+			want:   []string{"removed endpoint  true"},
+		},
+		{
+			name:   "added operation",
+			base:   `{"paths":{"/a":{"get":{}}}}`,
+			target: `{"paths":{"/a":{"get":{}},"/b":{"post":{}}}}`,
+			want:   []string{"added endpoint  false"},
+		},
+		{
+			name:   "new required param",
+			base:   diffOpSpec(``, ``, idName),
+			target: diffOpSpec(`{"name":"page","in":"query","required":true,"schema":{"type":"integer"}}`, ``, idName),
+			want:   []string{"added parameter query:page true"},
+		},
+		{
+			name:   "new optional param",
+			base:   diffOpSpec(``, ``, idName),
+			target: diffOpSpec(`{"name":"page","in":"query","schema":{"type":"integer"}}`, ``, idName),
+			want:   []string{"added parameter query:page false"},
+		},
+		{
+			name:   "param becomes required",
+			base:   diffOpSpec(`{"name":"page","in":"query","schema":{"type":"integer"}}`, ``, idName),
+			target: diffOpSpec(`{"name":"page","in":"query","required":true,"schema":{"type":"integer"}}`, ``, idName),
+			want:   []string{"changed parameter query:page true"},
+		},
+		{
+			name:   "removed response field",
+			base:   diffOpSpec(``, ``, idName),
+			target: diffOpSpec(``, ``, obj(`"id":{"type":"integer"}`)),
+			want:   []string{"removed response name true"},
+		},
+		{
+			name:   "added response field",
+			base:   diffOpSpec(``, ``, obj(`"id":{"type":"integer"}`)),
+			target: diffOpSpec(``, ``, idName),
+			want:   []string{"added response name false"},
+		},
+		{
+			name:   "removed optional request field",
+			base:   diffOpSpec(``, idName, idName),
+			target: diffOpSpec(``, obj(`"id":{"type":"integer"}`), idName),
+			want:   []string{"removed request name false"},
+		},
+		{
+			name:   "new required request field",
+			base:   diffOpSpec(``, obj(`"id":{"type":"integer"}`), idName),
+			target: diffOpSpec(``, obj(`"id":{"type":"integer"},"name":{"type":"string"}`, "name"), idName),
+			want:   []string{"added request name true"},
+		},
+		{
+			name:   "type change",
+			base:   diffOpSpec(``, ``, idName),
+			target: diffOpSpec(``, ``, obj(`"id":{"type":"string"},"name":{"type":"string"}`)),
+			want:   []string{"changed response id true"},
+		},
+		{
+			name:   "request enum value removed",
+			base:   diffOpSpec(`{"name":"s","in":"query","schema":{"type":"string","enum":["a","b"]}}`, ``, idName),
+			target: diffOpSpec(`{"name":"s","in":"query","schema":{"type":"string","enum":["a"]}}`, ``, idName),
+			want:   []string{"changed parameter query:s true"},
+		},
+		{
+			name:   "request enum value added",
+			base:   diffOpSpec(`{"name":"s","in":"query","schema":{"type":"string","enum":["a"]}}`, ``, idName),
+			target: diffOpSpec(`{"name":"s","in":"query","schema":{"type":"string","enum":["a","b"]}}`, ``, idName),
+			want:   []string{"changed parameter query:s false"},
+		},
+		{
+			name:   "response enum value added",
+			base:   diffOpSpec(``, ``, obj(`"st":{"type":"string","enum":["a"]}`)),
+			target: diffOpSpec(``, ``, obj(`"st":{"type":"string","enum":["a","b"]}`)),
+			want:   []string{"changed response st true"},
+		},
+		{
+			name:   "response enum value removed",
+			base:   diffOpSpec(``, ``, obj(`"st":{"type":"string","enum":["a","b"]}`)),
+			target: diffOpSpec(``, ``, obj(`"st":{"type":"string","enum":["a"]}`)),
+			want:   []string{"changed response st false"},
+		},
+		{
+			name:   "removed response status",
+			base:   `{"paths":{"/a":{"get":{"responses":{"200":{},"404":{}}}}}}`,
+			target: `{"paths":{"/a":{"get":{"responses":{"200":{}}}}}}`,
+			want:   []string{"removed response  true"},
+		},
+		{
+			name:   "unchanged",
+			base:   diffOpSpec(``, idName, idName),
+			target: diffOpSpec(``, idName, idName),
+			want:   nil,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rep := DiffSpecs(gjson.New(c.base), c.base, gjson.New(c.target), c.target)
+			var got []string
+			breaking := 0
+			for _, ch := range rep.Changes {
+				got = append(got, strings.Join([]string{ch.Kind, ch.Scope, ch.Field, map[bool]string{true: "true", false: "false"}[ch.Breaking]}, " "))
+				if ch.Breaking {
+					breaking++
+				}
+				if ch.Message == "" {
+					t.Errorf("change %+v has no message", ch)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(c.want, "\n") {
+				t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(c.want, "\n"))
+			}
+			if rep.Breaking != breaking || rep.NonBreaking != len(got)-breaking || rep.HasBreaking() != (breaking > 0) {
+				t.Errorf("counts = %d/%d, want %d/%d", rep.Breaking, rep.NonBreaking, breaking, len(got)-breaking)
+			}
+		})
+	}
+}
+
+// nonASCII 返回 s 中的第一个非 ASCII 字符（没有时为 0），用于确认英文报告没有残留中文文案。
+func nonASCII(s string) rune {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return r
+		}
+	}
+	return 0
+}
+
+func TestDiffSpecsEnglish(t *testing.T) {
+	base := diffOpSpec(`{"name":"s","in":"query","schema":{"type":"string","enum":["a","b"]}}`, ``, `{"type":"object","properties":{"id":{"type":"integer"},"name":{"type":"string"}}}`)
+	target := diffOpSpec(`{"name":"s","in":"query","required":true,"schema":{"type":"string","enum":["a","c"]}},{"name":"page","in":"query","required":true}`, `{"type":"object"}`, `{"type":"object","properties":{"id":{"type":"string"}}}`)
+	cfg := RenderConfig{Lang: LangEn}
+	rep := DiffSpecsWithConfig(gjson.New(base), base, gjson.New(target), target, cfg)
+	want := []string{
+		"Changed from optional to required",
+		"Enum values removed: b; Enum values added: c",
+		"Required parameter added",
+		"Request body added",
+		"Type changed from integer to string",
+		"Field removed",
+	}
+	var got []string
+	for _, c := range rep.Changes {
+		got = append(got, c.Message)
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("messages:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if r := nonASCII(RenderDiffMarkdown(rep, cfg)); r != 0 {
+		t.Errorf("english markdown contains %q", r)
+	}
+	zh := DiffSpecs(gjson.New(base), base, gjson.New(target), target)
+	if zh.Changes[0].Message != "由可选变为必填" {
+		t.Errorf("default language message = %q", zh.Changes[0].Message)
+	}
+}
+
+func TestGenerateDiffHTMLLinksDocsAnchors(t *testing.T) {
+	base := `{"paths":{"/a/{id}":{"get":{}},"/old":{"get":{}}}}`
+	target := `{"paths":{"/a/{id}":{"get":{}},"/a/id":{"get":{"operationId":"listA"}}}}`
+	cfg := RenderConfig{AnchorOperationID: true}
+	rep := DiffSpecsWithConfig(gjson.New(base), base, gjson.New(target), target, cfg)
+	anchors := OperationAnchors(gjson.New(target), target, cfg)
+	page := GenerateDiffHTML(rep, "/docs", anchors, cfg)
+	if !strings.Contains(page, `href="/docs#listA"`) {
+		t.Errorf("added operation is not linked to its operationId anchor")
+	}
+	if strings.Contains(page, `#get-old`) {
+		t.Errorf("removed operation must not be linked")
+	}
+	if a := anchors["GET /a/id"]; a != "listA" || !strings.Contains(GenerateHTMLWithConfig(gjson.New(target), target, cfg), `id="`+a+`"`) {
+		t.Errorf("anchor %q does not match the docs page", a)
+	}
+}
@@ -0,0 +1,266 @@
+package render
+
+import (
+	"encoding/json"
+	"html/template"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// ExampleInfo 规范中声明的命名示例（content.<媒体类型>.examples / example）。
+type ExampleInfo struct {
+	Name    string
+	Summary string
+	Value   string
+}
+
+// ResponseInfo 单个响应状态码的说明：字段、oneOf/anyOf 变体、自动生成的示例与规范声明的示例。
+type ResponseInfo struct {
+	Status      string
+	Description string
+	ContentType string
+	Fields      []FieldInfo
+	Variants    []VariantGroup
+	Example     string
+	Examples    []ExampleInfo
+}
+
+// buildEndpoint 汇总单个接口（path+method）的视图模型，HTML 与 Markdown 共用。
+// 说明：
+// - 参数、请求字段、返回字段以类型化切片提供，模板可自行渲染；
+// - 同时生成 *HTML 便捷片段（表格与转义后的示例），保持内置模板的输出不变；
+// - 定制规则按接口解析一次（customizeFor），Request/Response 白名单同时作用于表格与示例。
+func buildEndpoint(j *gjson.Json, p, m string, pj, mj *gjson.Json, cfg RenderConfig) EndpointData {
+	// 命中该接口的定制规则解析为一条，接口级覆盖、Header 注入与请求/返回字段规则共用
+	rules := customizeFor(p, m, mj, cfg)
+	ep := EndpointData{
+		Locale:      cfg.locale(),
+		Anchor:      anchorID(m, p),
+		Method:      m,
+		MethodUpper: strings.ToUpper(m),
+		Summary:     operationSummary(p, m, mj, rules),
+		Description: strings.TrimSpace(mj.Get("description").String()),
+		OperationID: mj.Get("operationId").String(),
+		Tags:        operationTags(mj, rules),
+		Path:        p,
+		Note:        rules.Note,
+		Warning:     rules.Warning,
+	}
+	ep.URLs = endpointURLs(endpointServers(j, pj, mj), p)
+	ep.Deprecation = deprecationOf(j, mj)
+	ep.DeprecatedText = deprecationText(ep.Deprecation, ep.Locale)
+	// 合并 path/op 两层 parameters 并按 in 分类，再注入定制参数
+	ps := applyCustomizeParams(collectParameters(j, pj, mj), rules)
+	ep.Headers, ep.PathParams, ep.QueryParams, ep.CookieParams = ps.Headers, ps.Path, ps.Query, ps.Cookie
+	// 认证要求：接口级 security 优先于文档级；经由 Header 传递的认证方式并入 Header 参数
+	ep.Security, ep.SecurityDeclared = effectiveSecurity(j, mj, securitySchemes(j))
+	ep.Headers = mergeHeaderParams(ep.Headers, securityHeaders(ep.Security, ep.Locale))
+	if cfg.HideDeprecated {
+		ep.Headers = withoutDeprecatedParams(ep.Headers)
+		ep.PathParams = withoutDeprecatedParams(ep.PathParams)
+		ep.QueryParams = withoutDeprecatedParams(ep.QueryParams)
+		ep.CookieParams = withoutDeprecatedParams(ep.CookieParams)
+	}
+	// 请求示例与参数表
+	reqSchema, reqCT, _ := getRequestSchema(j, mj)
+	ep.ContentType = reqCT
+	if rules.ContentType != "" {
+		ep.ContentType = rules.ContentType
+	} else if ep.ContentType == "" {
+		ep.ContentType = "application/json"
+	}
+	if reqSchema != nil {
+		ep.HasRequestBody = true
+		ep.RequestFields, ep.RequestVariants = schemaTables(j, reqSchema, rules.requestRules(), cfg.fieldView(viewRequest))
+		ep.RequestExample = exampleText(j, reqSchema, rules.requestRules(), cfg.fieldView(viewRequest))
+		_, media := pickMediaType(mj.GetJsonMap("requestBody.content"))
+		ep.RequestExamples = declaredExamples(j, media)
+	}
+	// 返回：逐个状态码生成说明，首选状态码（优先 200）作为主返回
+	primary := primaryResponseCode(mj)
+	codes := make([]string, 0, 4)
+	for c := range mj.GetJsonMap("responses") {
+		codes = append(codes, c)
+	}
+	sortStrings(codes)
+	for _, c := range codes {
+		ri := buildResponseInfo(j, mj.GetJson("responses."+c), c, rules.responseRules(), cfg.fieldView(viewResponse))
+		ep.Responses = append(ep.Responses, ri)
+		if c == primary && ri.Example != "" {
+			ep.ResponseStatus = c
+			ep.ResponseContentType = ri.ContentType
+			ep.ResponseFields = ri.Fields
+			ep.ResponseVariants = ri.Variants
+			ep.ResponseExample = ri.Example
+		}
+	}
+	// 便捷 HTML 片段
+	ep.SecurityHTML = template.HTML(renderSecurityHTML(ep.Security, ep.SecurityDeclared, ep.Locale))
+	if len(ep.Headers) > 0 {
+		ep.HeadersHTML = template.HTML(renderParamInfoTableHTML(ep.Headers, ep.Locale))
+	}
+	if len(ep.PathParams) > 0 {
+		ep.PathParamsHTML = template.HTML(renderParamInfoTableHTML(ep.PathParams, ep.Locale))
+	}
+	if len(ep.QueryParams) > 0 {
+		ep.QueryParamsHTML = template.HTML(renderParamInfoTableHTML(ep.QueryParams, ep.Locale))
+	}
+	if len(ep.CookieParams) > 0 {
+		ep.CookieParamsHTML = template.HTML(renderParamInfoTableHTML(ep.CookieParams, ep.Locale))
+	}
+	if ep.HasRequestBody {
+		ep.ReqExample = template.HTML(htmlEscape(ep.RequestExample))
+		ep.ReqTableHTML = template.HTML(renderRequestFieldTableHTML(ep.RequestFields, ep.Locale) + renderVariantGroupsHTML(ep.RequestVariants, true, ep.Locale))
+	}
+	if ep.ResponseExample != "" {
+		ep.ResExample = template.HTML(htmlEscape(ep.ResponseExample))
+		ep.ResTableHTML = template.HTML(renderResponseFieldTableHTML(ep.ResponseFields, ep.Locale) + renderVariantGroupsHTML(ep.ResponseVariants, false, ep.Locale))
+	}
+	return ep
+}
+
+// buildResponseInfo 解析单个响应（兼容 $ref），生成字段、示例与声明示例。
+func buildResponseInfo(j *gjson.Json, resp *gjson.Json, code string, fr fieldRules, view schemaView) ResponseInfo {
+	ri := ResponseInfo{Status: code}
+	if r := resp.Get("$ref").String(); r != "" {
+		resp = getRefJson(j, r)
+	}
+	if resp == nil {
+		return ri
+	}
+	ri.Description = resp.Get("description").String()
+	ct, media := pickMediaType(resp.GetJsonMap("content"))
+	ri.ContentType = ct
+	if media == nil {
+		return ri
+	}
+	ri.Examples = declaredExamples(j, media)
+	schema := responseSchemaFor(j, resp)
+	if schema == nil {
+		return ri
+	}
+	ri.Fields, ri.Variants = schemaTables(j, schema, fr, view)
+	ri.Example = exampleText(j, schema, fr, view)
+	return ri
+}
+
+// schemaTables 展开 schema 的基础字段与 oneOf/anyOf 变体，按字段规则（白名单、黑名单、重命名与覆盖）处理字段并生成各变体的示例。
+// 设置了白名单时不生成变体示例（白名单路径相对于整个请求/返回体，无法作用于变体片段）。
+func schemaTables(j *gjson.Json, s *gjson.Json, fr fieldRules, view schemaView) ([]FieldInfo, []VariantGroup) {
+	fields, groups := flattenSchema(j, s, "", view)
+	if view&viewResponse != 0 {
+		fields = envelopeFirst(j, s, fields, view)
+	}
+	fields = fr.applyToFields(filterFieldInfos(fields, fr.Allowed))
+	for gi := range groups {
+		g := &groups[gi]
+		for vi := range g.Variants {
+			v := &g.Variants[vi]
+			v.Fields = fr.applyToFields(filterFieldInfos(v.Fields, fr.Allowed))
+			if len(fr.Allowed) == 0 {
+				ex := fr.applyToExample(variantExample(j, *g, *v, view, map[string]bool{}), g.Path)
+				bs, _ := json.MarshalIndent(ex, "", "    ")
+				v.Example = string(bs)
+			}
+		}
+	}
+	return fields, groups
+}
+
+// exampleText 生成示例 JSON 文本（见 exampleValue）。
+func exampleText(j *gjson.Json, s *gjson.Json, fr fieldRules, view schemaView) string {
+	bs, _ := json.MarshalIndent(exampleValue(j, s, fr, view), "", "    ")
+	return string(bs)
+}
+
+// exampleValue 生成示例结构；设置白名单时过滤 data 内的叶子字段，再应用黑名单、示例覆盖与重命名。文档与模拟服务共用。
+func exampleValue(j *gjson.Json, s *gjson.Json, fr fieldRules, view schemaView) interface{} {
+	ex := exampleValueFromSchema(j, s, view)
+	if len(fr.Allowed) > 0 {
+		ex = filterExampleDataLeaves(ex, fr.Allowed)
+	}
+	return fr.applyToExample(ex, "")
+}
+
+// declaredExamples 提取媒体类型对象中声明的 example 与 examples（按名称排序，兼容 $ref）。
+func declaredExamples(j *gjson.Json, media *gjson.Json) []ExampleInfo {
+	if media == nil {
+		return nil
+	}
+	var res []ExampleInfo
+	if v := media.Get("example"); !v.IsNil() {
+		bs, _ := json.MarshalIndent(v.Val(), "", "    ")
+		res = append(res, ExampleInfo{Name: "example", Value: string(bs)})
+	}
+	named := media.GetJsonMap("examples")
+	names := make([]string, 0, len(named))
+	for k := range named {
+		names = append(names, k)
+	}
+	sortStrings(names)
+	for _, name := range names {
+		ex := named[name]
+		if r := ex.Get("$ref").String(); r != "" {
+			if ex = getRefJson(j, r); ex == nil {
+				continue
+			}
+		}
+		bs, _ := json.MarshalIndent(ex.Get("value").Val(), "", "    ")
+		res = append(res, ExampleInfo{Name: name, Summary: ex.Get("summary").String(), Value: string(bs)})
+	}
+	return res
+}
+
+// renderEndpointMarkdown 将接口视图模型渲染为 Markdown 片段：接口标题为 level 级，各小节依次下一级。
+func renderEndpointMarkdown(b *strings.Builder, ep EndpointData, level int) {
+	loc := ep.Locale
+	if ep.Deprecated {
+		b.WriteString(mdHeading(level, "~~"+ep.Summary+"~~ "+ep.DeprecatedText))
+	} else {
+		b.WriteString(mdHeading(level, ep.Summary))
+	}
+	if ep.Warning != "" {
+		b.WriteString(calloutMarkdown(loc.T("warning"), ep.Warning))
+	}
+	if ep.Note != "" {
+		b.WriteString(calloutMarkdown(loc.T("note"), ep.Note))
+	}
+	b.WriteString(mdHeading(level+1, loc.T("request_url")) + "`" + ep.Path + "`\n\n")
+	for _, u := range ep.URLs {
+		b.WriteString("- `" + u.URL + "`")
+		if u.Description != "" {
+			b.WriteString(" " + u.Description)
+		}
+		b.WriteString("\n")
+	}
+	if len(ep.URLs) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(mdHeading(level+1, loc.T("request_method")) + "- " + ep.MethodUpper + "  Content-Type: " + ep.ContentType + "\n\n")
+	if ep.SecurityDeclared {
+		b.WriteString(mdHeading(level+1, loc.T("security")) + renderSecurityMarkdown(ep.Security, ep.SecurityDeclared, loc))
+	}
+	if len(ep.Headers) > 0 {
+		b.WriteString(mdHeading(level+1, loc.T("headers")) + renderParamInfoTableMarkdown(ep.Headers, ep.Locale) + "\n")
+	}
+	if len(ep.PathParams) > 0 {
+		b.WriteString(mdHeading(level+1, loc.T("path_params")) + renderParamInfoTableMarkdown(ep.PathParams, ep.Locale) + "\n")
+	}
+	if len(ep.QueryParams) > 0 {
+		b.WriteString(mdHeading(level+1, loc.T("query_params")) + renderParamInfoTableMarkdown(ep.QueryParams, ep.Locale) + "\n")
+	}
+	if len(ep.CookieParams) > 0 {
+		b.WriteString(mdHeading(level+1, loc.T("cookie_params")) + renderParamInfoTableMarkdown(ep.CookieParams, ep.Locale) + "\n")
+	}
+	if ep.HasRequestBody {
+		b.WriteString(mdHeading(level+1, loc.T("request_example")) + "```json\n" + ep.RequestExample + "\n```\n\n")
+		b.WriteString(mdHeading(level+1, loc.T("request_params")) + renderRequestFieldTableMarkdown(ep.RequestFields, ep.Locale) + "\n")
+		b.WriteString(renderVariantGroupsMarkdown(ep.RequestVariants, true, ep.Locale, level+2))
+	}
+	if ep.ResponseExample != "" {
+		b.WriteString(mdHeading(level+1, loc.T("response_example")) + "```json\n" + ep.ResponseExample + "\n```\n\n")
+		b.WriteString(mdHeading(level+1, loc.T("response_params")) + renderResponseFieldTableMarkdown(ep.ResponseFields, ep.Locale) + "\n")
+		b.WriteString(renderVariantGroupsMarkdown(ep.ResponseVariants, false, ep.Locale, level+2))
+	}
+}
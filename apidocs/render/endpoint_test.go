@@ -0,0 +1,59 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// envelopeSpec 返回体 Page 以 data 包装对象列表；Plain 没有包装字段；Wrapped 以 payload 包装数组，dataset 不视为包装字段。
+const envelopeSpec = `{"paths":{
+	"/page":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Page"}}}}}}},
+	"/plain":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Plain"}}}}}}},
+	"/wrapped":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Wrapped"}}}}}}}},
+	"components":{"schemas":{
+		"Item":{"type":"object","properties":{"name":{"type":"string"},"id":{"type":"integer"}}},
+		"Page":{"type":"object","properties":{
+			"message":{"type":"string"},
+			"data":{"type":"object","properties":{"total":{"type":"integer"},"list":{"type":"array","items":{"$ref":"#/components/schemas/Item"}}}},
+			"code":{"type":"integer"},
+			"meta":{"type":"object","properties":{"trace":{"type":"string"}}}}},
+		"Plain":{"type":"object","properties":{"name":{"type":"string"},"id":{"type":"integer"}}},
+		"Wrapped":{"type":"object","properties":{
+			"dataset":{"type":"string"},
+			"payload":{"type":"array","items":{"$ref":"#/components/schemas/Item"}},
+			"code":{"type":"integer"}}}}}}`
+
+func TestResponseEnvelopeOrder(t *testing.T) {
+	j := gjson.New(envelopeSpec)
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"/page", "data,code,message,meta,meta.trace,data.list[],data.list[].id,data.list[].name,data.total"},
+		{"/plain", "id,name"},
+		{"/wrapped", "payload[],code,dataset,payload[].id,payload[].name"},
+	}
+	for _, c := range cases {
+		pj := j.GetJsonMap("paths")[c.path]
+		ep := buildEndpoint(j, c.path, "get", pj, pj.GetJson("get"), RenderConfig{})
+		var paths []string
+		for _, f := range ep.ResponseFields {
+			paths = append(paths, f.Path)
+		}
+		if got := strings.Join(paths, ","); got != c.want {
+			t.Errorf("%s response fields = %s, want %s", c.path, got, c.want)
+		}
+	}
+	// 请求参数表不调整行序
+	req := gjson.New(`{"type":"object","properties":{"data":{"type":"object","properties":{"id":{"type":"integer"}}},"code":{"type":"integer"}}}`)
+	fields, _ := schemaTables(j, req, fieldRules{}, viewRequest)
+	var paths []string
+	for _, f := range fields {
+		paths = append(paths, f.Path)
+	}
+	if got := strings.Join(paths, ","); got != "code,data,data.id" {
+		t.Errorf("request fields = %s", got)
+	}
+}
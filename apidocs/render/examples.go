@@ -1,135 +1,89 @@
 package render
 
 import (
-	"encoding/json"
 	"strings"
 
 	"github.com/gogf/gf/v2/encoding/gjson"
 )
 
-// exampleJSON 基于 $ref 生成 JSON 示例文本（缩进 4 空格）。
-func exampleJSON(j *gjson.Json, ref string) string {
-	ex := exampleValue(j, ref)
-	bs, _ := json.MarshalIndent(ex, "", "    ")
-	return string(bs)
+// exampleValueFromSchema 返回内联 schema 的示例结构（用于拼装示例 JSON）；view 决定剔除 readOnly 或 writeOnly 属性。
+func exampleValueFromSchema(j *gjson.Json, sj *gjson.Json, view schemaView) interface{} {
+	return exampleValueSeen(j, sj, view, map[string]bool{})
 }
 
-// exampleJSONFromSchema 基于内联 schema 生成 JSON 示例文本（缩进 4 空格）。
-func exampleJSONFromSchema(j *gjson.Json, s *gjson.Json) string {
-	ex := exampleValueFromSchema(j, s)
-	bs, _ := json.MarshalIndent(ex, "", "    ")
-	return string(bs)
-}
-
-// exampleValue 返回引用 schema 的示例结构（用于拼装示例 JSON）。
-func exampleValue(j *gjson.Json, ref string) interface{} {
-	name := componentNameFromRef(ref)
-	sj := getSchema(j, name)
-	if sj == nil {
-		return nil
-	}
-	if sj.Get("type").String() == "object" || len(sj.GetJsonMap("properties")) > 0 {
-		props := sj.GetJsonMap("properties")
-		m := map[string]interface{}{}
-		for k, pj := range props {
-			if rr := pj.Get("$ref").String(); rr != "" {
-				m[k] = exampleValue(j, rr)
-				continue
-			}
-			typ := pj.Get("type").String()
-			switch typ {
-			case "string":
-				m[k] = "string"
-			case "integer":
-				m[k] = 0
-			case "number":
-				m[k] = 0
-			case "boolean":
-				m[k] = false
-			default:
-				m[k] = nil
-			}
-		}
-		return m
-	}
-	switch sj.Get("type").String() {
-	case "string":
-		return "string"
-	case "integer":
-		return 0
-	case "number":
-		return 0
-	case "boolean":
-		return false
-	}
-	return nil
-}
-
-// exampleValueFromSchema 返回内联 schema 的示例结构（用于拼装示例 JSON）。
-func exampleValueFromSchema(j *gjson.Json, sj *gjson.Json) interface{} {
+// exampleValueSeen 递归生成示例；seen 记录当前链路上的 $ref，循环引用处输出 null。
+func exampleValueSeen(j *gjson.Json, sj *gjson.Json, view schemaView, seen map[string]bool) interface{} {
 	if sj == nil {
 		return nil
 	}
 	if rr := sj.Get("$ref").String(); rr != "" {
-		sub := getRefJson(j, rr)
-		return exampleValueFromSchema(j, sub)
+		if seen[rr] {
+			return nil
+		}
+		seen[rr] = true
+		defer delete(seen, rr)
+		return exampleValueSeen(j, getRefJson(j, rr), view, seen)
 	}
-	if sj.Get("type").String() == "array" {
+	typ := schemaTypeName(sj)
+	if typ == "array" {
 		it := sj.GetJson("items")
-		if it != nil {
-			if r2 := it.Get("$ref").String(); r2 != "" {
-				sub := getRefJson(j, r2)
-				return []interface{}{exampleValueFromSchema(j, sub)}
-			} else if it.Get("type").String() == "object" || len(it.GetJsonMap("properties")) > 0 || len(it.Get("allOf").Array()) > 0 || len(it.Get("oneOf").Array()) > 0 || len(it.Get("anyOf").Array()) > 0 {
-				return []interface{}{exampleValueFromSchema(j, it)}
-			} else {
-				return []interface{}{}
-			}
+		if it.Get("$ref").String() != "" || isObjectSchema(it) {
+			return []interface{}{exampleValueSeen(j, it, view, seen)}
 		}
 		return []interface{}{}
 	}
-	if sj.Get("type").String() == "object" || len(sj.GetJsonMap("properties")) > 0 {
-		props := sj.GetJsonMap("properties")
+	if typ == "object" || isObjectSchema(sj) {
+		// 基础属性：自身 properties 与 allOf 合并
+		props, _ := mergedObject(j, sj, view, map[string]bool{})
 		m := map[string]interface{}{}
 		for k, pj := range props {
-			if rr := pj.Get("$ref").String(); rr != "" {
-				sub := getRefJson(j, rr)
-				m[k] = exampleValueFromSchema(j, sub)
+			m[k] = propertyExample(j, pj, view, seen)
+		}
+		// additionalProperties/patternProperties：以样例键填入值示例
+		entries := mapEntries(sj)
+		for i, e := range entries {
+			if len(e.Schema.Map()) == 0 {
 				continue
 			}
-			typ := pj.Get("type").String()
-			switch typ {
-			case "string":
-				m[k] = "string"
-			case "integer":
-				m[k] = 0
-			case "number":
-				m[k] = 0
-			case "boolean":
-				m[k] = false
-			case "object":
-				m[k] = exampleValueFromSchema(j, pj)
-			case "array":
-				it := pj.GetJson("items")
-				if it != nil {
-					if r2 := it.Get("$ref").String(); r2 != "" {
-						sub := getRefJson(j, r2)
-						m[k] = []interface{}{exampleValueFromSchema(j, sub)}
-					} else if it.Get("type").String() == "object" || len(it.GetJsonMap("properties")) > 0 || len(it.Get("allOf").Array()) > 0 || len(it.Get("oneOf").Array()) > 0 || len(it.Get("anyOf").Array()) > 0 {
-						m[k] = []interface{}{exampleValueFromSchema(j, it)}
-					} else {
-						m[k] = []interface{}{}
-					}
-				} else {
-					m[k] = []interface{}{}
+			m[sampleMapKey(sj, i, len(entries))] = propertyExample(j, e.Schema, view, seen)
+		}
+		// oneOf/anyOf：取第一个变体，并填入其区分字段取值，保证示例与某个变体一致
+		if g := variantGroupOf(j, sj, ""); g != nil && len(g.Variants) > 0 {
+			ex := variantExample(j, *g, g.Variants[0], view, seen)
+			vm, ok := ex.(map[string]interface{})
+			if !ok {
+				if len(m) == 0 {
+					return ex
 				}
-			default:
-				m[k] = nil
+				return m
+			}
+			for k, v := range vm {
+				m[k] = v
 			}
 		}
 		return m
 	}
-	switch sj.Get("type").String() {
+	return leafExample(sj)
+}
+
+// propertyExample 属性值示例：引用、对象与数组递归生成，基础类型取叶子示例。
+func propertyExample(j *gjson.Json, pj *gjson.Json, view schemaView, seen map[string]bool) interface{} {
+	switch pt := schemaTypeName(pj); {
+	case pj.Get("$ref").String() != "", pt == "object", pt == "array", pt == "" && isObjectSchema(pj):
+		return exampleValueSeen(j, pj, view, seen)
+	}
+	return leafExample(pj)
+}
+
+// leafExample 基础类型字段的示例值：优先 default，其次首个枚举值，否则按类型取占位值。
+func leafExample(s *gjson.Json) interface{} {
+	if v := s.Get("default"); !v.IsNil() {
+		return v.Val()
+	}
+	if e := s.Get("enum").Array(); len(e) > 0 {
+		return e[0]
+	}
+	switch schemaTypeName(s) {
 	case "string":
 		return "string"
 	case "integer":
@@ -144,7 +98,7 @@ func exampleValueFromSchema(j *gjson.Json, sj *gjson.Json) interface{} {
 
 // filterExampleDataLeaves 按白名单过滤示例中的 data 字段：
 // - 顶层 code/message/data 始终保留；
-// - 允许完整路径与叶子名简写；数组路径 .items[] 与 [] 等价；
+// - 允许完整路径与叶子名简写；数组的 [] 也可写作 .items[]；
 // - 仅保留叶子基础类型，跳过对象中间节点。
 func filterExampleDataLeaves(ex interface{}, allowed []string) interface{} {
 	aset := make(map[string]struct{}, len(allowed))
@@ -152,11 +106,7 @@ func filterExampleDataLeaves(ex interface{}, allowed []string) interface{} {
 		if a == "" {
 			continue
 		}
-		a = strings.ReplaceAll(a, ".items", "")
-		for strings.Contains(a, "[][]") {
-			a = strings.ReplaceAll(a, "[][]", "[]")
-		}
-		aset[a] = struct{}{}
+		aset[normalizeFieldKey(a)] = struct{}{}
 	}
 	m, ok := ex.(map[string]interface{})
 	if !ok {
@@ -219,18 +169,19 @@ func pathJoin(prefix, key string) string {
 	return prefix + "." + key
 }
 
-// matchAllowedPath 判断完整路径是否命中白名单（兼容 [] 与移除 [] 的两种形式）。
+// matchAllowedPath 判断完整路径是否命中白名单：路径的各等价写法（[] 与 .items[]，见 fieldPathSpellings）
+// 及其移除 [] 的形式均可命中。
 func matchAllowedPath(aset map[string]struct{}, path string) bool {
-	if _, ok := aset[path]; ok {
-		return true
-	}
-	p1 := strings.ReplaceAll(path, "[].", "[]")
-	if _, ok := aset[p1]; ok {
-		return true
-	}
-	p2 := strings.ReplaceAll(path, "[]", "")
-	if _, ok := aset[p2]; ok {
-		return true
+	for _, s := range fieldPathSpellings(path) {
+		if _, ok := aset[s]; ok {
+			return true
+		}
+		if _, ok := aset[strings.ReplaceAll(s, "[].", "[]")]; ok {
+			return true
+		}
+		if _, ok := aset[strings.ReplaceAll(s, "[]", "")]; ok {
+			return true
+		}
 	}
 	return false
 }
@@ -246,19 +197,3 @@ func isNonEmpty(v interface{}) bool {
 		return v != nil
 	}
 }
-
-// exampleJSONFromSchemaWithAllowed 基于内联 schema 生成示例并应用白名单过滤。
-func exampleJSONFromSchemaWithAllowed(j *gjson.Json, s *gjson.Json, allowed []string) string {
-	ex := exampleValueFromSchema(j, s)
-	ex = filterExampleDataLeaves(ex, allowed)
-	bs, _ := json.MarshalIndent(ex, "", "    ")
-	return string(bs)
-}
-
-// exampleJSONWithAllowed 基于 $ref 生成示例并应用白名单过滤。
-func exampleJSONWithAllowed(j *gjson.Json, ref string, allowed []string) string {
-	ex := exampleValue(j, ref)
-	ex = filterExampleDataLeaves(ex, allowed)
-	bs, _ := json.MarshalIndent(ex, "", "    ")
-	return string(bs)
-}
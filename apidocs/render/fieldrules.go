@@ -0,0 +1,234 @@
+package render
+
+import (
+	"sort"
+	"strings"
+)
+
+// FieldOverride 单个字段的展示覆盖：非空的 Desc/Type 替换字段表中的说明与类型，非 nil 的 Example 替换示例中的取值。
+type FieldOverride struct {
+	Desc    string
+	Type    string
+	Example interface{}
+}
+
+// fieldRules 作用于请求体或返回体的字段规则（表格与示例共用）。
+// 规则键与白名单写法一致：字段名（任意层级）或完整路径（数组的 [] 也可写作 .items[]），均指规范中的原始字段名。
+type fieldRules struct {
+	Allowed []string
+	Exclude []string
+	Rename  map[string]string
+	Fields  map[string]FieldOverride
+}
+
+// requestRules 请求体字段规则。
+func (r CustomizeReqAndRes) requestRules() fieldRules {
+	return fieldRules{Allowed: r.Request, Exclude: r.Exclude, Rename: r.Rename, Fields: r.Fields}
+}
+
+// responseRules 返回体字段规则。
+func (r CustomizeReqAndRes) responseRules() fieldRules {
+	return fieldRules{Allowed: r.Response, Exclude: r.Exclude, Rename: r.Rename, Fields: r.Fields}
+}
+
+// empty 是否未设置黑名单、重命名与覆盖（白名单另行处理）。
+func (fr fieldRules) empty() bool {
+	return len(fr.Exclude) == 0 && len(fr.Rename) == 0 && len(fr.Fields) == 0
+}
+
+// normalizeFieldKey 归一化字段规则键：连续的 [] 合并；.items[] 写法在匹配时按字段路径展开（见 fieldPathSpellings），不在此处改写。
+func normalizeFieldKey(a string) string {
+	for strings.Contains(a, "[][]") {
+		a = strings.ReplaceAll(a, "[][]", "[]")
+	}
+	return a
+}
+
+// fieldPathSpellings 字段路径的等价写法：数组字段的 [] 也可写作 .items[]，路径末尾的 [] 还可写作 .items，
+// 如 data.list[].id 与 data.list.items[].id 等价。只展开路径中真实的数组标记，名为 items（或 itemsCount 等）的属性保持原样。
+func fieldPathSpellings(path string) []string {
+	res := []string{""}
+	rest := path
+	for {
+		i := strings.Index(rest, "[]")
+		if i < 0 {
+			break
+		}
+		next := make([]string, 0, len(res)*2)
+		for _, r := range res {
+			s := r + rest[:i]
+			next = append(next, s+"[]")
+			if s != "" && !strings.HasSuffix(s, "]") && !strings.HasSuffix(s, ".") {
+				next = append(next, s+".items[]")
+			}
+		}
+		res, rest = next, rest[i+2:]
+	}
+	for i := range res {
+		res[i] += rest
+	}
+	if rest == "" {
+		for _, r := range res {
+			if strings.HasSuffix(r, ".items[]") {
+				res = append(res, strings.TrimSuffix(r, "[]"))
+			}
+		}
+	}
+	return res
+}
+
+// isFieldPathKey 规则键是否为完整路径（含 . 或 []），否则为字段名。
+func isFieldPathKey(key string) bool {
+	return strings.ContainsAny(key, ".[]")
+}
+
+// fieldName 字段路径的最后一段名称（去除数组标记），如 data.items[] → items。
+func fieldName(path string) string {
+	path = strings.TrimRight(path, "[]")
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		path = path[i+1:]
+	}
+	return strings.ReplaceAll(path, "[]", "")
+}
+
+// fieldKeyMatch 判断规则键是否命中字段路径：完整路径按白名单相同的规则比较，字段名匹配任意层级的同名字段。
+func fieldKeyMatch(key, path string) bool {
+	key = normalizeFieldKey(key)
+	if key == "" || path == "" {
+		return false
+	}
+	if !isFieldPathKey(key) {
+		return fieldName(path) == key
+	}
+	return matchAllowedPath(map[string]struct{}{key: {}}, path)
+}
+
+// lookupFieldRule 查找命中字段路径的规则：完整路径优先于字段名，同类按键的字典序取第一条。
+func lookupFieldRule[T any](rules map[string]T, path string) (T, bool) {
+	var zero T
+	if len(rules) == 0 {
+		return zero, false
+	}
+	keys := make([]string, 0, len(rules))
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		pa, pb := isFieldPathKey(keys[a]), isFieldPathKey(keys[b])
+		if pa != pb {
+			return pa
+		}
+		return keys[a] < keys[b]
+	})
+	for _, k := range keys {
+		if fieldKeyMatch(k, path) {
+			return rules[k], true
+		}
+	}
+	return zero, false
+}
+
+// fieldAncestors 字段路径自身及其各级父路径，如 data.items[].x → data、data.items、data.items[]、data.items[].x。
+func fieldAncestors(path string) []string {
+	var res []string
+	for i := 1; i < len(path); i++ {
+		if path[i] == '.' || (path[i] == '[' && path[i-1] != ']' && path[i-1] != '.') {
+			res = append(res, path[:i])
+		}
+	}
+	return append(res, path)
+}
+
+// excluded 字段或其任一父字段命中黑名单时返回 true。
+func (fr fieldRules) excluded(path string) bool {
+	for _, p := range fieldAncestors(path) {
+		for _, k := range fr.Exclude {
+			if fieldKeyMatch(k, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renamePath 按重命名规则改写字段路径中的每一段（以原始路径匹配），保留数组标记。
+func (fr fieldRules) renamePath(path string) string {
+	if len(fr.Rename) == 0 {
+		return path
+	}
+	parts := strings.Split(path, ".")
+	orig := ""
+	for i, part := range parts {
+		name := strings.TrimRight(part, "[]")
+		suffix := part[len(name):]
+		cur := pathJoin(orig, name)
+		if name != "" {
+			if n, ok := lookupFieldRule(fr.Rename, cur); ok && n != "" {
+				parts[i] = n + suffix
+			}
+		}
+		orig = pathJoin(orig, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// applyToFields 对字段行应用黑名单、覆盖与重命名（规则均按原始路径匹配）。
+func (fr fieldRules) applyToFields(fields []FieldInfo) []FieldInfo {
+	if fr.empty() {
+		return fields
+	}
+	out := make([]FieldInfo, 0, len(fields))
+	for _, f := range fields {
+		if fr.excluded(f.Path) {
+			continue
+		}
+		if o, ok := lookupFieldRule(fr.Fields, f.Path); ok {
+			if o.Desc != "" {
+				f.Desc = o.Desc
+			}
+			if o.Type != "" {
+				f.Type = o.Type
+			}
+		}
+		f.Path = fr.renamePath(f.Path)
+		out = append(out, f)
+	}
+	return out
+}
+
+// applyToExample 对示例值应用黑名单、示例覆盖与重命名；prefix 为示例根所在的字段路径（变体示例为分组位置）。
+func (fr fieldRules) applyToExample(v interface{}, prefix string) interface{} {
+	if fr.empty() {
+		return v
+	}
+	switch t := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(t))
+		for k, vv := range t {
+			p := pathJoin(prefix, k)
+			if fr.excluded(p) {
+				continue
+			}
+			// 数组字段在字段表中为 p[] 行，按该行的规则判断（如 data.list.items）
+			if _, ok := vv.([]interface{}); ok && fr.excluded(p+"[]") {
+				continue
+			}
+			vv = fr.applyToExample(vv, p)
+			if o, ok := lookupFieldRule(fr.Fields, p); ok && o.Example != nil {
+				vv = o.Example
+			}
+			if n, ok := lookupFieldRule(fr.Rename, p); ok && n != "" {
+				k = n
+			}
+			res[k] = vv
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, 0, len(t))
+		for _, it := range t {
+			res = append(res, fr.applyToExample(it, prefix+"[]"))
+		}
+		return res
+	}
+	return v
+}
@@ -0,0 +1,192 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// templateFuncs 返回所有模板可用的内置函数，并合并 RenderConfig.Funcs（同名时用户函数优先）。
+// 内置函数：
+// - anchor METHOD PATH：接口在文档页中的锚点（与接口块的 id 一致，含冲突后缀与 operationId 锚点；接口不在页面中时为方法+路径生成的缺省锚点）
+// - slugify S：锚点友好的短串
+// - markdown S：将说明文本按简化 Markdown 渲染为 HTML（先转义，再处理代码块、列表、标题与行内格式）
+// - json V：缩进格式化的 JSON 文本
+// - methodClass M：方法配色 class，如 method-get
+// - formatType T：规范化类型显示（去除组件名装饰）
+// - lower/upper S：大小写转换
+// - dict K V ...：构造 map[string]interface{}，便于向子模板传多个参数
+// - list V ...：构造 []interface{}
+func templateFuncs(cfg RenderConfig) template.FuncMap {
+	fm := template.FuncMap{
+		"anchor":      anchorID,
+		"slugify":     slugify,
+		"markdown":    markdownHTML,
+		"json":        prettyJSON,
+		"methodClass": methodClass,
+		"formatType":  formatType,
+		"lower":       strings.ToLower,
+		"upper":       strings.ToUpper,
+		"dict":        dict,
+		"list":        list,
+	}
+	for k, v := range cfg.Funcs {
+		fm[k] = v
+	}
+	return fm
+}
+
+// prettyJSON 以 4 空格缩进格式化任意值；字符串若本身是 JSON 则重新格式化，否则原样返回。
+func prettyJSON(v interface{}) string {
+	if s, ok := v.(string); ok {
+		var x interface{}
+		if err := json.Unmarshal([]byte(s), &x); err != nil {
+			return s
+		}
+		v = x
+	}
+	bs, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return ""
+	}
+	return string(bs)
+}
+
+// methodClass 返回方法对应的 class 名（method-get/method-post 等）。
+func methodClass(m string) string {
+	return "method-" + strings.ToLower(strings.TrimSpace(m))
+}
+
+// formatType 与参数表一致的类型显示：清理多余的 object 修饰并去除组件名装饰。
+func formatType(t string) string {
+	return stripComponentTypeDecorations(sanitizeType(t))
+}
+
+// dict 将成对的键值参数构造为 map，键必须为字符串。
+func dict(kv ...interface{}) (map[string]interface{}, error) {
+	if len(kv)%2 != 0 {
+		return nil, fmt.Errorf("dict: odd number of arguments")
+	}
+	m := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		k, ok := kv[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", kv[i])
+		}
+		m[k] = kv[i+1]
+	}
+	return m, nil
+}
+
+// list 将参数构造为切片。
+func list(v ...interface{}) []interface{} {
+	return v
+}
+
+var (
+	mdHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdListRe    = regexp.MustCompile(`^\s*(?:[-*+]|\d+\.)\s+(.*)$`)
+	mdCodeRe    = regexp.MustCompile("`([^`]+)`")
+	mdBoldRe    = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdEmRe      = regexp.MustCompile(`\*([^*]+)\*`)
+	mdLinkRe    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// markdownHTML 将说明文本渲染为 HTML 片段。
+// 仅支持常见子集：段落、``` 代码块、无序/有序列表、# 标题（降级为 h4-h6 以免打乱文档层级）、
+// 行内代码、粗体、斜体与链接；链接仅允许 http/https/mailto、站内路径与锚点。
+func markdownHTML(s string) template.HTML {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "\r\n", "\n")
+	if s == "" {
+		return ""
+	}
+	var b strings.Builder
+	var para []string
+	inList, inCode := false, false
+	flushPara := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + strings.Join(para, "<br>") + "</p>")
+			para = para[:0]
+		}
+	}
+	closeList := func() {
+		if inList {
+			b.WriteString("</ul>")
+			inList = false
+		}
+	}
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			if inCode {
+				b.WriteString("</code></pre>")
+			} else {
+				flushPara()
+				closeList()
+				b.WriteString("<pre><code>")
+			}
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			b.WriteString(htmlEscape(line) + "\n")
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			flushPara()
+			closeList()
+			continue
+		}
+		if m := mdHeadingRe.FindStringSubmatch(line); m != nil {
+			flushPara()
+			closeList()
+			level := len(m[1]) + 3
+			if level > 6 {
+				level = 6
+			}
+			fmt.Fprintf(&b, "<h%d>%s</h%d>", level, markdownInline(m[2]), level)
+			continue
+		}
+		if m := mdListRe.FindStringSubmatch(line); m != nil {
+			flushPara()
+			if !inList {
+				b.WriteString("<ul>")
+				inList = true
+			}
+			b.WriteString("<li>" + markdownInline(m[1]) + "</li>")
+			continue
+		}
+		closeList()
+		para = append(para, markdownInline(line))
+	}
+	if inCode {
+		b.WriteString("</code></pre>")
+	}
+	flushPara()
+	closeList()
+	return template.HTML(b.String())
+}
+
+// markdownInline 处理行内格式；输入先整体转义，保证不会注入原始 HTML。
+func markdownInline(s string) string {
+	s = htmlEscape(strings.TrimSpace(s))
+	s = mdCodeRe.ReplaceAllString(s, "<code>$1</code>")
+	s = mdLinkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdLinkRe.FindStringSubmatch(m)
+		if !safeLink(sub[2]) {
+			return sub[1]
+		}
+		return `<a href="` + sub[2] + `">` + sub[1] + `</a>`
+	})
+	s = mdBoldRe.ReplaceAllString(s, "<strong>$1</strong>")
+	s = mdEmRe.ReplaceAllString(s, "<em>$1</em>")
+	return s
+}
+
+// safeLink 仅放行 http/https/mailto、站内路径与锚点。
+func safeLink(u string) bool {
+	l := strings.ToLower(u)
+	return strings.HasPrefix(l, "http://") || strings.HasPrefix(l, "https://") ||
+		strings.HasPrefix(l, "mailto:") || strings.HasPrefix(l, "/") || strings.HasPrefix(l, "#")
+}
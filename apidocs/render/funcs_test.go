@@ -0,0 +1,81 @@
+package render
+
+import (
+	"html/template"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+func TestMarkdownHTML(t *testing.T) {
+	cases := []struct {
+		name, in string
+		want     template.HTML
+	}{
+		{"empty", "  ", ""},
+		{"paragraph lines", "a\nb\n\nc", "<p>a<br>b</p><p>c</p>"},
+		{"heading is demoted", "# T\n### U", "<h4>T</h4><h6>U</h6>"},
+		{"list", "- a\n1. **b**", "<ul><li>a</li><li><strong>b</strong></li></ul>"},
+		{"code block is escaped", "```\n<b>\n```", "<pre><code>&lt;b&gt;\n</code></pre>"},
+		{"inline", "`x` *y* [d](https://e.com)", `<p><code>x</code> <em>y</em> <a href="https://e.com">d</a></p>`},
+		{"html is escaped", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"unsafe link drops href", "[x](javascript:void)", "<p>x</p>"},
+		{"site links allowed", "[a](/docs) [b](#top)", `<p><a href="/docs">a</a> <a href="#top">b</a></p>`},
+	}
+	for _, c := range cases {
+		if got := markdownHTML(c.in); got != c.want {
+			t.Errorf("%s: markdownHTML(%q) = %q, want %q", c.name, c.in, got, c.want)
+		}
+	}
+}
+
+func TestTemplateFuncHelpers(t *testing.T) {
+	if got := prettyJSON(`{"a":1}`); got != "{\n    \"a\": 1\n}" {
+		t.Errorf("prettyJSON(json string) = %q", got)
+	}
+	if got := prettyJSON("plain"); got != "plain" {
+		t.Errorf("prettyJSON(text) = %q", got)
+	}
+	if got := prettyJSON([]int{1}); got != "[\n    1\n]" {
+		t.Errorf("prettyJSON(slice) = %q", got)
+	}
+	if got := methodClass(" POST "); got != "method-post" {
+		t.Errorf("methodClass = %q", got)
+	}
+	if got, err := dict("a", 1, "b", "x"); err != nil || !reflect.DeepEqual(got, map[string]interface{}{"a": 1, "b": "x"}) {
+		t.Errorf("dict = %v, %v", got, err)
+	}
+	if _, err := dict("a"); err == nil {
+		t.Errorf("dict with odd arguments must fail")
+	}
+	if _, err := dict(1, 2); err == nil {
+		t.Errorf("dict with a non-string key must fail")
+	}
+	if got := list(1, "a"); !reflect.DeepEqual(got, []interface{}{1, "a"}) {
+		t.Errorf("list = %v", got)
+	}
+}
+
+func TestCustomFuncsOverrideBuiltins(t *testing.T) {
+	fm := templateFuncs(RenderConfig{Funcs: template.FuncMap{"upper": strings.ToLower, "shout": strings.ToUpper}})
+	for _, name := range []string{"anchor", "slugify", "markdown", "json", "methodClass", "formatType", "lower", "upper", "dict", "list", "shout"} {
+		if fm[name] == nil {
+			t.Errorf("func %s missing", name)
+		}
+	}
+	if reflect.ValueOf(fm["upper"]).Pointer() != reflect.ValueOf(strings.ToLower).Pointer() {
+		t.Errorf("custom func must replace the builtin with the same name")
+	}
+	dir := writeTemplateDir(t, map[string]string{"layout.tmpl": `{{define "layout"}}{{shout .Title}}|{{upper .Title}}|{{json (dict "k" (list 1))}}{{end}}`})
+	got := GenerateHTMLWithConfig(gjson.New(`{"info":{"title":"Docs"},"paths":{}}`), "", RenderConfig{
+		TemplateFS: []fs.FS{os.DirFS(dir)},
+		Funcs:      template.FuncMap{"upper": strings.ToLower, "shout": strings.ToUpper},
+	})
+	if want := "DOCS|docs|{\n    &#34;k&#34;: [\n        1\n    ]\n}"; got != want {
+		t.Errorf("page = %q, want %q", got, want)
+	}
+}
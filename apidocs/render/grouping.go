@@ -0,0 +1,117 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/tools"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// 分组策略（RenderConfig.GroupBy）。
+const (
+	// GroupByTag 按 tags[0] 分组（缺省）
+	GroupByTag = "tag"
+	// GroupByTags 按全部标签分组，接口在每个标签对应的分组下各列一次
+	GroupByTags = "tags"
+	// GroupByPath 按路径前缀段分组（跳过 {参数} 段），段数由 GroupPathDepth 指定
+	GroupByPath = "path"
+	// GroupByExtension 按接口的 x-group 扩展字段分组（字符串或字符串数组），未设置时回退到 tags[0]
+	GroupByExtension = "x-group"
+)
+
+// GroupFunc 自定义分组函数：返回接口所属的分组，每个分组为从一级到末级的名称列表（如 {"用户中心", "查询"}）；
+// 返回多个分组时接口在每个分组下各列一次，返回空时归入“未分组”。
+type GroupFunc func(path, method string, op *gjson.Json) [][]string
+
+// defaultGroupPathDepth 按路径分组时缺省使用的前缀段数。
+const defaultGroupPathDepth = 2
+
+// groupDelimiter 分组字符串的层级分隔符，缺省 "/"。
+func (cfg RenderConfig) groupDelimiter() string {
+	if cfg.GroupDelimiter == "" {
+		return "/"
+	}
+	return cfg.GroupDelimiter
+}
+
+// operationGroups 按分组策略计算接口所属的分组（层级名称列表，已去重）：
+// Customize.Group 优先（按 GroupDelimiter 拆分），其次为 GroupFunc，最后按 GroupBy。
+func (cfg RenderConfig) operationGroups(p, m string, mj *gjson.Json, rules CustomizeReqAndRes) [][]string {
+	delim := cfg.groupDelimiter()
+	var groups [][]string
+	switch {
+	case strings.TrimSpace(rules.Group) != "":
+		groups = [][]string{tools.SplitGroupPath(rules.Group, delim)}
+	case cfg.GroupFunc != nil:
+		groups = cfg.GroupFunc(p, m, mj)
+	default:
+		tags := jsonArrayStrings(mj.Get("tags").Array())
+		switch strings.ToLower(cfg.GroupBy) {
+		case GroupByTags:
+			for _, t := range tags {
+				groups = append(groups, tools.SplitGroupPath(t, delim))
+			}
+		case GroupByPath:
+			groups = [][]string{pathGroup(p, cfg.GroupPathDepth)}
+		case GroupByExtension:
+			v := mj.Get("x-group")
+			switch {
+			case v.IsSlice():
+				for _, g := range v.Strings() {
+					groups = append(groups, tools.SplitGroupPath(g, delim))
+				}
+			case v.String() != "":
+				groups = [][]string{tools.SplitGroupPath(v.String(), delim)}
+			case len(tags) > 0:
+				groups = [][]string{tools.SplitGroupPath(tags[0], delim)}
+			}
+		default:
+			if len(tags) > 0 {
+				groups = [][]string{tools.SplitGroupPath(tags[0], delim)}
+			}
+		}
+	}
+	res := make([][]string, 0, len(groups))
+	seen := map[string]bool{}
+	for _, g := range groups {
+		if len(g) == 0 {
+			continue
+		}
+		k := strings.Join(g, "\x00")
+		if !seen[k] {
+			seen[k] = true
+			res = append(res, g)
+		}
+	}
+	return res
+}
+
+// pathGroup 路径的前 depth 个非参数段，如 /v1/users/{id}/orders（depth=2）→ [v1 users]。
+func pathGroup(p string, depth int) []string {
+	if depth <= 0 {
+		depth = defaultGroupPathDepth
+	}
+	var res []string
+	for _, seg := range strings.Split(p, "/") {
+		if seg == "" || strings.HasPrefix(seg, "{") {
+			continue
+		}
+		res = append(res, seg)
+		if len(res) == depth {
+			break
+		}
+	}
+	return res
+}
+
+// groupParts 将分组层级转为导航使用的（一级, 次级）：次级为其余层级以 "/" 连接；缺失时取缺省名称。
+func groupParts(g []string, loc Locale) (string, string) {
+	if len(g) == 0 {
+		return loc.T("ungrouped"), loc.T("default_group")
+	}
+	if len(g) == 1 {
+		return g[0], loc.T("default_group")
+	}
+	return g[0], strings.Join(g[1:], "/")
+}
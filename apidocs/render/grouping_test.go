@@ -0,0 +1,88 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// formatGroups 将分组列表格式化为 "a>b|c" 便于比较。
+func formatGroups(groups [][]string) string {
+	parts := make([]string, 0, len(groups))
+	for _, g := range groups {
+		parts = append(parts, strings.Join(g, ">"))
+	}
+	return strings.Join(parts, "|")
+}
+
+func TestOperationGroups(t *testing.T) {
+	cases := []struct {
+		name  string
+		cfg   RenderConfig
+		path  string
+		op    string
+		rules CustomizeReqAndRes
+		want  string
+	}{
+		{"tag default", RenderConfig{}, "/a", `{"tags":["用户/管理/查询","订单"]}`, CustomizeReqAndRes{}, "用户>管理>查询"},
+		{"tag skips empty segments", RenderConfig{GroupBy: GroupByTag}, "/a", `{"tags":["/用户//管理/"]}`, CustomizeReqAndRes{}, "用户>管理"},
+		{"tag custom delimiter", RenderConfig{GroupDelimiter: "::"}, "/a", `{"tags":["用户::管理/查询"]}`, CustomizeReqAndRes{}, "用户>管理/查询"},
+		{"tag without tags", RenderConfig{}, "/a", `{}`, CustomizeReqAndRes{}, ""},
+		{"tags lists every tag", RenderConfig{GroupBy: GroupByTags}, "/a", `{"tags":["用户/管理","订单","用户/管理"]}`, CustomizeReqAndRes{}, "用户>管理|订单"},
+		{"tags custom delimiter", RenderConfig{GroupBy: "TAGS", GroupDelimiter: "."}, "/a", `{"tags":["用户.管理","订单"]}`, CustomizeReqAndRes{}, "用户>管理|订单"},
+		{"path default depth", RenderConfig{GroupBy: GroupByPath}, "/v1/{tenant}/users/{id}/orders", `{"tags":["x"]}`, CustomizeReqAndRes{}, "v1>users"},
+		{"path depth 1", RenderConfig{GroupBy: GroupByPath, GroupPathDepth: 1}, "/v1/users", `{}`, CustomizeReqAndRes{}, "v1"},
+		{"path depth beyond segments", RenderConfig{GroupBy: GroupByPath, GroupPathDepth: 5}, "/v1/users/{id}", `{}`, CustomizeReqAndRes{}, "v1>users"},
+		{"path root", RenderConfig{GroupBy: GroupByPath}, "/", `{}`, CustomizeReqAndRes{}, ""},
+		{"x-group string", RenderConfig{GroupBy: GroupByExtension}, "/a", `{"x-group":"运维/监控","tags":["x"]}`, CustomizeReqAndRes{}, "运维>监控"},
+		{"x-group array", RenderConfig{GroupBy: GroupByExtension, GroupDelimiter: "."}, "/a", `{"x-group":["运维.监控","告警"]}`, CustomizeReqAndRes{}, "运维>监控|告警"},
+		{"x-group falls back to tags[0]", RenderConfig{GroupBy: GroupByExtension}, "/a", `{"tags":["用户/管理","订单"]}`, CustomizeReqAndRes{}, "用户>管理"},
+		{"x-group without tags", RenderConfig{GroupBy: GroupByExtension}, "/a", `{}`, CustomizeReqAndRes{}, ""},
+		{"unknown strategy uses tags[0]", RenderConfig{GroupBy: "other"}, "/a", `{"tags":["用户/管理","订单"]}`, CustomizeReqAndRes{}, "用户>管理"},
+		{"GroupFunc", RenderConfig{GroupBy: GroupByTags, GroupFunc: func(p, m string, op *gjson.Json) [][]string {
+			return [][]string{{"自定义", strings.ToUpper(m)}, nil, {"自定义", strings.ToUpper(m)}}
+		}}, "/a", `{"tags":["x"]}`, CustomizeReqAndRes{}, "自定义>GET"},
+		{"Customize.Group first", RenderConfig{GroupBy: GroupByPath, GroupDelimiter: ".", GroupFunc: func(p, m string, op *gjson.Json) [][]string {
+			return [][]string{{"自定义"}}
+		}}, "/v1/a", `{"tags":["x"]}`, CustomizeReqAndRes{Group: "文件.上传"}, "文件>上传"},
+		{"blank Customize.Group ignored", RenderConfig{}, "/a", `{"tags":["x"]}`, CustomizeReqAndRes{Group: " "}, "x"},
+	}
+	for _, c := range cases {
+		got := c.cfg.operationGroups(c.path, "get", gjson.New(c.op), c.rules)
+		if s := formatGroups(got); s != c.want {
+			t.Errorf("%s: groups = %q, want %q", c.name, s, c.want)
+		}
+	}
+}
+
+func TestGroupPartsFallback(t *testing.T) {
+	en := RenderConfig{Lang: LangEn}.locale()
+	cases := []struct {
+		g        []string
+		loc      Locale
+		pre, suf string
+	}{
+		{nil, en, "Ungrouped", en.T("default_group")},
+		{nil, RenderConfig{}.locale(), "未分组", "默认"},
+		{[]string{"用户"}, en, "用户", en.T("default_group")},
+		{[]string{"用户", "管理", "查询"}, en, "用户", "管理/查询"},
+	}
+	for _, c := range cases {
+		if pre, suf := groupParts(c.g, c.loc); pre != c.pre || suf != c.suf {
+			t.Errorf("groupParts(%v) = %q, %q, want %q, %q", c.g, pre, suf, c.pre, c.suf)
+		}
+	}
+
+	// 没有分组的接口归入“未分组/默认”
+	raw := `{"paths":{"/":{"get":{}},"/a":{"get":{"tags":["A"]}}}}`
+	j := gjson.New(raw)
+	cfg := RenderConfig{Lang: LangEn, GroupBy: GroupByPath}
+	var got []string
+	for _, op := range collectOperations(j, raw, cfg, loadTagCatalog(j, "/")) {
+		got = append(got, op.Path+"@"+op.Pre+"/"+op.Suf)
+	}
+	if want := "/@Ungrouped/" + en.T("default_group") + ",/a@a/" + en.T("default_group"); strings.Join(got, ",") != want {
+		t.Errorf("operations = %s, want %s", strings.Join(got, ","), want)
+	}
+}
@@ -0,0 +1,234 @@
+package render
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/source"
+	"github.com/megatrZlp/go-apidocs/apidocs/tools"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// TagInfo 文档级 tags 中声明的标签：x-displayName 为展示名称，Description 支持 Markdown。
+type TagInfo struct {
+	Name         string
+	DisplayName  string
+	Description  string
+	ExternalDocs *ExternalDocInfo
+}
+
+// tagGroup x-tagGroups 中的一项：在“主/次”分组之上再增加一级。
+type tagGroup struct {
+	Name string
+	Tags []string
+}
+
+// tagCatalog 文档级 tags 与 x-tagGroups，决定分组顺序、展示名称与说明。
+// 标签名按分组路径匹配：名为 “主” 的标签描述一级分组，名为 “主/次” 的标签描述次级分组。
+type tagCatalog struct {
+	tags   map[string]TagInfo
+	order  []string
+	groups []tagGroup
+}
+
+// loadTagCatalog 读取文档级 tags（含 description、externalDocs、x-displayName）与 x-tagGroups；
+// 标签名按分组分隔符拆分后以 "/" 连接，与导航中的分组路径对应。
+func loadTagCatalog(j *gjson.Json, delim string) *tagCatalog {
+	norm := func(name string) string {
+		return strings.Join(tools.SplitGroupPath(name, delim), "/")
+	}
+	c := &tagCatalog{tags: map[string]TagInfo{}}
+	for _, v := range j.Get("tags").Array() {
+		tj := gjson.New(v)
+		name := norm(tj.Get("name").String())
+		if name == "" {
+			continue
+		}
+		if _, ok := c.tags[name]; ok {
+			continue
+		}
+		t := TagInfo{
+			Name:        name,
+			DisplayName: strings.TrimSpace(tj.Get("x-displayName").String()),
+			Description: strings.TrimSpace(tj.Get("description").String()),
+		}
+		if u := tj.Get("externalDocs.url").String(); u != "" {
+			t.ExternalDocs = &ExternalDocInfo{URL: u, Description: tj.Get("externalDocs.description").String()}
+		}
+		c.tags[name] = t
+		c.order = append(c.order, name)
+	}
+	for _, v := range j.Get("x-tagGroups").Array() {
+		gj := gjson.New(v)
+		g := tagGroup{Name: strings.TrimSpace(gj.Get("name").String())}
+		for _, t := range jsonArrayStrings(gj.Get("tags").Array()) {
+			g.Tags = append(g.Tags, norm(t))
+		}
+		if g.Name != "" && len(g.Tags) > 0 {
+			c.groups = append(c.groups, g)
+		}
+	}
+	return c
+}
+
+// tag 分组路径（“主” 或 “主/次”）对应的标签说明；未声明时仅含名称。
+func (c *tagCatalog) tag(path string) TagInfo {
+	if t, ok := c.tags[path]; ok {
+		return t
+	}
+	return TagInfo{Name: path}
+}
+
+// label 分组路径的展示名称；fallback 为未声明 x-displayName 时使用的名称（通常为路径的最后一段）。
+func (c *tagCatalog) label(path, fallback string) string {
+	if t, ok := c.tags[path]; ok && t.DisplayName != "" {
+		return t.DisplayName
+	}
+	return fallback
+}
+
+// tagUnder 标签名是否等于分组路径或位于其下（如 “用户中心/查询” 位于 “用户中心” 下）。
+func tagUnder(tag, path string) bool {
+	return tag == path || strings.HasPrefix(tag, path+"/")
+}
+
+// rank 分组路径在文档级 tags 中的位置：取该路径及其下级标签的最小下标，未声明时返回 -1。
+func (c *tagCatalog) rank(path string) int {
+	for i, name := range c.order {
+		if tagUnder(name, path) {
+			return i
+		}
+	}
+	return -1
+}
+
+// groupOf 一级分组所属的 x-tagGroups 项及其在该项 tags 中的位置；未归属时返回 (-1, -1)。
+func (c *tagCatalog) groupOf(pre string) (int, int) {
+	for gi, g := range c.groups {
+		for ti, t := range g.Tags {
+			if tagUnder(t, pre) {
+				return gi, ti
+			}
+		}
+	}
+	return -1, -1
+}
+
+// docOperation 文档中一个可见的接口及其分组。
+type docOperation struct {
+	Path, Method string
+	PathItem, Op *gjson.Json
+	Rules        CustomizeReqAndRes
+	Pre, Suf     string
+	// Anchor 接口块的锚点（由 assignAnchors 分配）；同一接口出现在多个分组下时各不相同
+	Anchor string
+	order  operationSortKey
+	// TagGroup x-tagGroups 的名称；使用 x-tagGroups 时未归属任何一项的分组为空串
+	TagGroup string
+}
+
+// collectOperations 按 paths 原始顺序收集可见接口（跳过隐藏与按配置省略的接口），按分组策略归入分组
+// （接口属于多个分组时在每个分组下各出现一次），再按文档级标签排序：
+// - 设置了 x-tagGroups 时先按其顺序，未归属的分组排在最后；
+// - 一级分组与次级分组按文档级 tags 中的位置排序，未声明者按首次出现的顺序排在已声明者之后；
+// - 同一分组内按 x-order、OperationOrder 与 MethodOrder 排序（见 operationLess）。
+func collectOperations(j *gjson.Json, contentRaw string, cfg RenderConfig, cat *tagCatalog) []docOperation {
+	loc := cfg.locale()
+	paths := j.GetJsonMap("paths")
+	keys := source.OrderedPathsFromContent(contentRaw)
+	if len(keys) == 0 {
+		for k := range paths {
+			keys = append(keys, k)
+		}
+		sortStrings(keys)
+	}
+	var ops []docOperation
+	for pi, p := range keys {
+		pj := paths[p]
+		for _, m := range presentMethods(pj) {
+			mj := pj.GetJson(m)
+			if mj == nil {
+				continue
+			}
+			rules := customizeFor(p, m, mj, cfg)
+			if cfg.hidesOperation(j, mj, rules) {
+				continue
+			}
+			groups := cfg.operationGroups(p, m, mj, rules)
+			if len(groups) == 0 {
+				groups = [][]string{nil}
+			}
+			for _, g := range groups {
+				pre, suf := groupParts(g, loc)
+				ops = append(ops, docOperation{Path: p, Method: m, PathItem: pj, Op: mj, Rules: rules, Pre: pre, Suf: suf, order: cfg.sortKey(p, m, mj, rules, pi)})
+			}
+		}
+	}
+	sortOperations(ops, cat, cfg)
+	return ops
+}
+
+// sortOperations 按 x-tagGroups、文档级 tags 与首次出现顺序对分组稳定排序，分组内按 cfg.operationLess 排序，并填充 TagGroup。
+func sortOperations(ops []docOperation, cat *tagCatalog, cfg RenderConfig) {
+	seen := map[string]int{}
+	first := func(key string) int {
+		if i, ok := seen[key]; ok {
+			return i
+		}
+		seen[key] = len(seen)
+		return seen[key]
+	}
+	type keyed struct {
+		op                        docOperation
+		group, groupPos, pre, suf int
+	}
+	rankOf := func(path string) int {
+		if r := cat.rank(path); r >= 0 {
+			return r
+		}
+		return len(cat.order) + first(path)
+	}
+	list := make([]keyed, len(ops))
+	for i, op := range ops {
+		k := keyed{op: op, group: len(cat.groups), groupPos: -1}
+		if gi, ti := cat.groupOf(op.Pre); gi >= 0 {
+			k.group, k.groupPos = gi, ti
+			k.op.TagGroup = cat.groups[gi].Name
+		}
+		k.pre = rankOf(op.Pre)
+		k.suf = rankOf(op.Pre + "/" + op.Suf)
+		list[i] = k
+	}
+	sort.SliceStable(list, func(a, b int) bool {
+		x, y := list[a], list[b]
+		if x.group != y.group {
+			return x.group < y.group
+		}
+		if x.groupPos != y.groupPos {
+			return x.groupPos < y.groupPos
+		}
+		if x.pre != y.pre {
+			return x.pre < y.pre
+		}
+		if x.suf != y.suf {
+			return x.suf < y.suf
+		}
+		return cfg.operationLess(x.op.order, y.op.order)
+	})
+	for i, k := range list {
+		ops[i] = k.op
+	}
+}
+
+// renderTagMarkdown 分组标题下的标签说明与外部文档链接（Markdown）；均未声明时返回空串。
+func renderTagMarkdown(t TagInfo, loc Locale) string {
+	var b strings.Builder
+	if t.Description != "" {
+		b.WriteString(t.Description + "\n\n")
+	}
+	if t.ExternalDocs != nil {
+		b.WriteString(loc.T("external_docs") + ": " + markdownLink(t.ExternalDocs.Description, t.ExternalDocs.URL) + "\n\n")
+	}
+	return b.String()
+}
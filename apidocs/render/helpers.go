@@ -46,11 +46,6 @@ func slugify(s string) string {
 	return tools.Slugify(s)
 }
 
-// splitTagParts 将 tags[0] 按“主/次”分组，例如 “用户模块/用户管理”。
-func splitTagParts(tags []string) (string, string) {
-	return tools.SplitTagParts(tags)
-}
-
 // componentNameFromRef 从 $ref 中提取末尾组件名。
 func componentNameFromRef(ref string) string {
 	return tools.ComponentNameFromRef(ref)
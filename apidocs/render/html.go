@@ -2,32 +2,71 @@ package render
 
 import (
 	"html/template"
+	"io/fs"
 	"strings"
 
-	"github.com/megatrZlp/go-apidocs/apidocs/source"
-	"github.com/megatrZlp/go-apidocs/apidocs/tools"
-
 	"github.com/gogf/gf/v2/encoding/gjson"
 )
 
-// CustomizeReqAndRes 定义“按接口路径”的注入 Header 与请求/返回白名单规则。
+// CustomizeReqAndRes 定义“按接口”的注入 Header 与请求/返回白名单规则；RenderConfig.Customize 的键为选择器（见 ruleSelector）。
+// - Headers: 以 "type#required#desc" 字符串注入 Header；Params: 结构化注入 header/query/path/cookie 参数（同名时优先于 Headers）
+// - Exclude: 字段黑名单，请求体与返回体共用，命中的字段连同子字段从表格与示例中移除
+// - Rename: 字段重命名（原字段 → 新名称），Fields: 字段说明/类型/示例覆盖；键与白名单写法一致
+// - Hide/Summary/Group/Note/Warning/ContentType: 接口级覆盖（隐藏、标题、分组、说明与警告提示、请求 Content-Type）
 type CustomizeReqAndRes struct {
-	Headers  map[string]string
-	Request  []string
-	Response []string
+	Headers     map[string]string
+	Params      []ParamSpec
+	Request     []string
+	Response    []string
+	Exclude     []string
+	Rename      map[string]string
+	Fields      map[string]FieldOverride
+	Hide        bool
+	Summary     string
+	Group       string
+	Note        string
+	Warning     string
+	ContentType string
 }
 
-// RenderConfig 渲染配置：包含外层路由配置映射与模板路径。
+// RenderConfig 渲染配置：包含外层路由配置映射、模板来源与模板函数。
 type RenderConfig struct {
 	RouteMarkdown string
 	Customize     map[string]CustomizeReqAndRes
-	TemplateDir   string
+	// TemplateFS 模板来源（embed.FS、os.DirFS、zip.Reader 等），靠前者优先，其后依次为 TemplateDir 与内置模板
+	TemplateFS  []fs.FS
+	TemplateDir string
+	// HideDeprecated 为 true 时不输出已废弃的接口、参数与字段（HTML 与 Markdown）
+	HideDeprecated bool
+	// DevMode 为 true 时每次渲染重新读取并解析模板；否则解析结果按模板来源缓存
+	DevMode bool
+	// Funcs 合并到内置模板函数库中的自定义函数（同名覆盖内置函数）
+	Funcs template.FuncMap
+	// Lang 界面语言（内置 zh-CN、en，缺省 zh-CN）
+	Lang string
+	// Locales 自定义语言目录，按语言代码覆盖或新增文案；缺失的键回退到简体中文
+	Locales map[string]Locale
+	// GroupBy 分组策略：tag（缺省，tags[0]）、tags（全部标签）、path（路径前缀段）、x-group（扩展字段）
+	GroupBy string
+	// GroupDelimiter 标签、x-group 与 Customize.Group 中分组层级的分隔符（缺省 "/"，如 "."、"::"）
+	GroupDelimiter string
+	// GroupPathDepth 按路径分组时使用的前缀段数（缺省 2）
+	GroupPathDepth int
+	// GroupFunc 自定义分组函数，设置后优先于 GroupBy（Customize.Group 仍然优先）
+	GroupFunc GroupFunc
+	// OperationOrder 分组内接口的排序：spec（缺省，paths 原始顺序）、path（路径字母序）、summary（标题字母序）；
+	// 声明了 x-order 的接口始终按其升序排在前面
+	OperationOrder string
+	// MethodOrder 同一路径下方法的排序：alpha（缺省，字母序）、canonical（GET、POST、PUT、PATCH、DELETE…）
+	MethodOrder string
+	// AnchorOperationID 为 true 时接口锚点优先使用 operationId（缺省为方法+路径）；旧锚点由页面脚本跳转
+	AnchorOperationID bool
 }
 
 // GenerateHTML 生成完整的 HTML 文档页面。
 // 行为说明：
 // - 读取 OpenAPI 的 info、paths、tags、requestBody、responses 等字段；
-// - 侧边导航使用 OrderedPathsFromContent(contentRaw) 保持 paths 原始顺序；
+// - 分组按文档级 tags 与 x-tagGroups 排序，分组内使用 OrderedPathsFromContent(contentRaw) 保持 paths 原始顺序；
 // - 每个分组（一级/二级）与接口块都会生成可链接的锚点；
 // - 请求/响应参数说明支持 $ref 与内联 schema，同时递归处理数组 items；
 // - 页面包含最简样式与交互：展开/收起导航；滚动时菜单联动高亮。
@@ -36,31 +75,11 @@ func GenerateHTML(j *gjson.Json, contentRaw string) string {
 	return GenerateHTMLWithConfig(j, contentRaw, cfg)
 }
 
-// applyCustomizeHeaders 为指定路径注入自定义 Header 参数，避免重复。
-func applyCustomizeHeaders(path string, headers []paramInfo, cfg RenderConfig) []paramInfo {
-	rules := customizeForPath(path, cfg)
-	if len(rules.Headers) == 0 {
-		return headers
-	}
-	exists := make(map[string]struct{}, len(headers))
-	for _, h := range headers {
-		exists[h.Name] = struct{}{}
-	}
-	for name, typ := range rules.Headers {
-		if _, ok := exists[name]; ok {
-			continue
-		}
-		t, req, desc := parseHeaderSpec(typ)
-		headers = append(headers, paramInfo{Name: name, Required: req, Type: t, Desc: desc})
-	}
-	return headers
-}
-
 // parseHeaderSpec 解析自定义 Header 规格：形如 "type#required#desc" 或 "type#desc"。
-func parseHeaderSpec(spec string) (typ string, required string, desc string) {
+func parseHeaderSpec(spec string) (typ string, required bool, desc string) {
 	parts := strings.Split(spec, "#")
 	typ = ""
-	required = "否"
+	required = false
 	desc = ""
 	if len(parts) > 0 {
 		typ = parts[0]
@@ -68,12 +87,12 @@ func parseHeaderSpec(spec string) (typ string, required string, desc string) {
 	if len(parts) >= 2 {
 		p1 := strings.ToLower(strings.TrimSpace(parts[1]))
 		if p1 == "required" || p1 == "必选" {
-			required = "是"
+			required = true
 			if len(parts) >= 3 {
 				desc = parts[2]
 			}
 		} else if p1 == "optional" || p1 == "可选" {
-			required = "否"
+			required = false
 			if len(parts) >= 3 {
 				desc = parts[2]
 			} else {
@@ -89,27 +108,57 @@ func parseHeaderSpec(spec string) (typ string, required string, desc string) {
 	return typ, required, desc
 }
 
-// pathLikeMatch 支持 * 通配的路径匹配。
-func pathLikeMatch(pattern, path string) bool {
-	if pattern == "" {
-		return false
-	}
-	if !strings.Contains(pattern, "*") {
-		return pattern == path
-	}
-	segs := strings.Split(pattern, "*")
-	i := 0
-	for _, s := range segs {
-		if s == "" {
-			continue
+// customizeFor 解析命中接口的全部规则（见 ruleSelector），按优先级从高到低合并：
+// - Headers、Params（按位置+名称）、Rename、Fields 逐项取优先级最高的规则；
+// - Request/Response 白名单取声明了该项（非 nil）的最高优先级规则，不与其他规则合并；
+// - Exclude 黑名单取全部命中规则的并集，任一规则设置 Hide 即隐藏接口；
+// - Summary/Group/Note/Warning/ContentType 取设置了该项（非空）的最高优先级规则。
+func customizeFor(path, method string, op *gjson.Json, cfg RenderConfig) CustomizeReqAndRes {
+	res := CustomizeReqAndRes{Headers: map[string]string{}}
+	reqSet, resSet := false, false
+	for _, s := range matchingSelectors(cfg.Customize, path, method, op) {
+		r := cfg.Customize[s.Key]
+		for k, v := range r.Headers {
+			if _, ok := res.Headers[k]; !ok {
+				res.Headers[k] = v
+			}
+		}
+		for _, p := range r.Params {
+			if !hasParam(res.Params, p) {
+				res.Params = append(res.Params, p)
+			}
+		}
+		if r.Request != nil && !reqSet {
+			res.Request, reqSet = r.Request, true
 		}
-		idx := strings.Index(path[i:], s)
-		if idx < 0 {
-			return false
+		if r.Response != nil && !resSet {
+			res.Response, resSet = r.Response, true
+		}
+		res.Exclude = uniqueMerge(res.Exclude, r.Exclude)
+		res.Hide = res.Hide || r.Hide
+		res.Summary = firstNonEmpty(res.Summary, r.Summary)
+		res.Group = firstNonEmpty(res.Group, r.Group)
+		res.Note = firstNonEmpty(res.Note, r.Note)
+		res.Warning = firstNonEmpty(res.Warning, r.Warning)
+		res.ContentType = firstNonEmpty(res.ContentType, r.ContentType)
+		for k, v := range r.Rename {
+			if _, ok := res.Rename[k]; !ok {
+				if res.Rename == nil {
+					res.Rename = map[string]string{}
+				}
+				res.Rename[k] = v
+			}
+		}
+		for k, v := range r.Fields {
+			if _, ok := res.Fields[k]; !ok {
+				if res.Fields == nil {
+					res.Fields = map[string]FieldOverride{}
+				}
+				res.Fields[k] = v
+			}
 		}
-		i += idx + len(s)
 	}
-	return true
+	return res
 }
 
 // uniqueMerge 合并两个字符串切片，跳过空项并去重，保持原有顺序（后者追加到前者）。
@@ -136,77 +185,46 @@ func uniqueMerge(a []string, b []string) []string {
 	return a
 }
 
-// customizeForPath 根据路径匹配（支持 * 通配）聚合多个规则，返回合并后的 Headers/Request/Response 白名单。
-func customizeForPath(path string, cfg RenderConfig) CustomizeReqAndRes {
-	res := CustomizeReqAndRes{Headers: map[string]string{}}
-	for pat, r := range cfg.Customize {
-		if !pathLikeMatch(pat, path) {
-			continue
-		}
-		if r.Headers != nil {
-			for k, v := range r.Headers {
-				if _, ok := res.Headers[k]; !ok {
-					res.Headers[k] = v
-				}
-			}
-		}
-		if r.Request != nil {
-			res.Request = uniqueMerge(res.Request, r.Request)
-		}
-		if r.Response != nil {
-			res.Response = uniqueMerge(res.Response, r.Response)
-		}
-	}
-	return res
-}
-
 // GenerateHTMLWithConfig 与 GenerateHTML 一致，但支持 RenderConfig.Customize 的 Header 注入与 Req/Res 过滤。
 func GenerateHTMLWithConfig(j *gjson.Json, contentRaw string, cfg RenderConfig) string {
-	// 标题：优先使用 OpenAPI info.title，缺省为 “API 文档”
-	title := strings.TrimSpace(j.Get("info.title").String())
-	if title == "" {
-		title = "API 文档"
-	}
+	// 标题与文档头部：info、externalDocs 与 servers；标题缺省为 “API 文档”
+	loc := cfg.locale()
+	info := docInfo(j, loc.T("title"))
+	title := info.Title
 	// 构建模板，若失败则采用降级路径输出布局 HTML
-	t, terr := buildLayoutTemplate(cfg.TemplateDir)
-	// paths 结构与其键原始顺序（通过流式解析 raw 内容保证菜单与正文一致）
-	paths := j.GetJsonMap("paths")
-	keys := source.OrderedPathsFromContent(contentRaw)
-	groups := make(map[string]map[string][][2]string)
-	pfxOrder := make([]string, 0, len(keys))
+	t, terr := buildLayoutTemplate(cfg)
+	// 可见接口按文档级 tags 与 x-tagGroups 排序（paths 原始顺序通过流式解析 raw 内容获得），菜单与正文共用
+	cat := loadTagCatalog(j, cfg.groupDelimiter())
+	ops := collectOperations(j, contentRaw, cfg, cat)
+	// 锚点统一登记，保证唯一；旧写法的锚点由页面脚本跳转到新锚点
+	reg := newAnchorRegistry()
+	anchors := cfg.assignAnchors(ops, len(cat.groups) > 0, reg, loc)
+	// 模板函数 anchor 按本页分配的锚点解析（用户以同名函数覆盖时不替换）
+	if _, ok := cfg.Funcs["anchor"]; !ok && terr == nil {
+		t.Funcs(template.FuncMap{"anchor": anchors.operation})
+	}
+	groups := make(map[string]map[string][]NavItemVM)
+	pfxOrder := make([]string, 0, len(ops))
 	sfxOrder := make(map[string][]string)
-	// 遍历所有路径，按 tags[0] 的“主/次”分组，将 (summary, anchor) 聚合到分组树
-	for _, p := range keys {
-		pj := paths[p]
-		methods := presentMethods(pj)
-		for _, m := range methods {
-			mj := pj.GetJson(m)
-			if mj == nil {
-				continue
-			}
-			tags := jsonArrayStrings(mj.Get("tags").Array())
-			pre, suf := splitTagParts(tags)
-			summary := strings.TrimSpace(mj.Get("summary").String())
-			if summary == "" {
-				summary = strings.ToUpper(m) + " " + p
-			}
-			anchor := anchorID(m, p)
-			if _, ok := groups[pre]; !ok {
-				groups[pre] = make(map[string][][2]string)
-				pfxOrder = append(pfxOrder, pre)
-			}
-			if _, ok := groups[pre][suf]; !ok {
-				groups[pre][suf] = make([][2]string, 0, 8)
-				sfxOrder[pre] = append(sfxOrder[pre], suf)
-			}
-			groups[pre][suf] = append(groups[pre][suf], [2]string{summary, anchor})
+	// 按 tags[0] 的“主/次”分组，将 (summary, anchor) 聚合到分组树
+	for _, op := range ops {
+		pre, suf := op.Pre, op.Suf
+		if _, ok := groups[pre]; !ok {
+			groups[pre] = make(map[string][]NavItemVM)
+			pfxOrder = append(pfxOrder, pre)
 		}
+		if _, ok := groups[pre][suf]; !ok {
+			groups[pre][suf] = make([]NavItemVM, 0, 8)
+			sfxOrder[pre] = append(sfxOrder[pre], suf)
+		}
+		item := NavItemVM{Summary: operationSummary(op.Path, op.Method, op.Op, op.Rules), Anchor: op.Anchor, Deprecated: deprecationOf(j, op.Op).Deprecated}
+		groups[pre][suf] = append(groups[pre][suf], item)
 	}
 	// 侧边导航视图模型（按主/次分组的树结构）
-	navVM := buildTopNavGroups(pfxOrder, sfxOrder, groups)
+	navVM := buildTopNavGroups(pfxOrder, sfxOrder, groups, cat, anchors)
 	var bn strings.Builder
 	if terr == nil {
-		_ = t.ExecuteTemplate(&bn, "nav", NavData{Groups: navVM})
+		_ = t.ExecuteTemplate(&bn, "nav", NavData{Locale: loc, Groups: navVM, TagGroups: buildNavTagGroups(ops, navVM, cat, anchors, loc)})
 	}
 	var bm strings.Builder
 	mdRoute := cfg.RouteMarkdown
@@ -214,131 +232,49 @@ func GenerateHTMLWithConfig(j *gjson.Json, contentRaw string, cfg RenderConfig)
 		mdRoute = "/docs.md"
 	}
 	if terr == nil {
-		_ = t.ExecuteTemplate(&bm, "main_header", MainHeaderData{Title: tools.HTMLEscape(title), MdRoute: tools.HTMLEscape(mdRoute)})
-	}
-	var currPre string
+		schemes := securitySchemes(j)
+		_ = t.ExecuteTemplate(&bm, "main_header", MainHeaderData{
+			Locale:          loc,
+			Title:           title,
+			MdRoute:         mdRoute,
+			Info:            info,
+			SecuritySchemes: schemes,
+			SecurityHTML:    template.HTML(renderSecuritySchemesHTML(schemes, loc)),
+		})
+	}
+	var currPre, currTagGroup string
 	emittedSub := make(map[string]bool)
 	// 主体正文：按菜单顺序生成分组标题与接口块
-	for _, p := range keys {
-		pj := paths[p]
-		methods := presentMethods(pj)
-		for _, m := range methods {
-			mj := pj.GetJson(m)
-			if mj == nil {
-				continue
-			}
-			summary := strings.TrimSpace(mj.Get("summary").String())
-			tags := tools.JSONArrayStrings(mj.Get("tags").Array())
-			_, reqCT, reqRef := getRequestSchema(j, mj)
-			_, _, resRef := getResponseSchema(j, mj)
-			pre, _ := tools.SplitTagParts(tags)
-			if pre != currPre {
-				currPre = pre
-				if terr == nil {
-					_ = t.ExecuteTemplate(&bm, "group_heading", GroupHeadingData{Id: "group-" + slugify(pre), Name: htmlEscape(pre)})
-				}
-			}
+	for i, op := range ops {
+		pre, suf := op.Pre, op.Suf
+		if terr == nil && len(cat.groups) > 0 && (i == 0 || op.TagGroup != currTagGroup) {
+			currTagGroup = op.TagGroup
+			name := firstNonEmpty(op.TagGroup, loc.T("other_tags"))
+			_ = t.ExecuteTemplate(&bm, "tag_group_heading", TagGroupHeadingData{Id: anchors.tagGroup(name), Name: name})
+		}
+		if pre != currPre {
+			currPre = pre
 			if terr == nil {
-				_, suf := tools.SplitTagParts(tags)
-				childAcc := "group-" + tools.Slugify(pre) + "-" + tools.Slugify(suf)
-				if _, ok := emittedSub[childAcc]; !ok {
-					// 次级分组标题（只输出一次）
-					_ = t.ExecuteTemplate(&bm, "sub_heading", SubHeadingData{Id: childAcc, Name: htmlEscape(suf)})
-					emittedSub[childAcc] = true
-				}
-			}
-			anchor := tools.AnchorID(m, p)
-			ct := reqCT
-			if ct == "" {
-				ct = "application/json"
-			}
-			// 合并 path/op 两层 parameters 并按 in 分类
-			headers, pathsParams, queryParams := collectParameters(j, pj, mj)
-			headers = applyCustomizeHeaders(p, headers, cfg)
-			// 请求示例与参数表（优先内联 schema，其次 $ref）
-			reqSchema, _, reqRef2 := getRequestSchema(j, mj)
-			if reqRef == "" {
-				reqRef = reqRef2
-			}
-			allowedReq := cfg.Customize[p].Request
-			var reqExample string
-			var reqTable string
-			if reqSchema != nil {
-				if allowedReq == nil {
-					reqExample = exampleJSONFromSchema(j, reqSchema)
-					reqTable = renderParamTableHTMLFromJson(j, reqSchema)
-				} else {
-					reqExample = exampleJSONFromSchemaWithAllowed(j, reqSchema, allowedReq)
-					reqTable = renderParamTableHTMLFromJsonWithAllowed(j, reqSchema, allowedReq)
-				}
-			} else if reqRef != "" {
-				if allowedReq == nil {
-					reqExample = exampleJSON(j, reqRef)
-					reqTable = renderParamTableHTML(j, reqRef)
-				} else {
-					reqExample = exampleJSONWithAllowed(j, reqRef, allowedReq)
-					reqTable = renderParamTableHTMLWithAllowed(j, reqRef, allowedReq)
-				}
-			}
-			// 返回示例与参数表（优先 200，首选 JSON 媒体类型）
-			resSchema, _, resRef2 := getResponseSchema(j, mj)
-			if resRef == "" {
-				resRef = resRef2
+				_ = t.ExecuteTemplate(&bm, "group_heading", GroupHeadingData{Locale: loc, Id: anchors.group(pre), Name: cat.label(pre, pre), Tag: cat.tag(pre)})
 			}
-			allowedRes := cfg.Customize[p].Response
-			var resExample string
-			var resTable string
-			if resSchema != nil {
-				if allowedRes == nil {
-					resExample = exampleJSONFromSchema(j, resSchema)
-					resTable = tools.StripComponentTypeDecorations(renderResponseParamFlatTableHTMLFromJson(j, resSchema))
-				} else {
-					resExample = exampleJSONFromSchemaWithAllowed(j, resSchema, allowedRes)
-					resTable = tools.StripComponentTypeDecorations(renderResponseParamFlatTableHTMLFromJsonWithAllowed(j, resSchema, allowedRes))
-				}
-			} else if resRef != "" {
-				if allowedRes == nil {
-					resExample = exampleJSON(j, resRef)
-					resTable = tools.StripComponentTypeDecorations(renderResponseParamFlatTableHTMLFromRef(j, resRef))
-				} else {
-					rs := getRefJson(j, resRef)
-					if rs != nil {
-						resExample = exampleJSONWithAllowed(j, resRef, allowedRes)
-						resTable = tools.StripComponentTypeDecorations(renderResponseParamFlatTableHTMLFromJsonWithAllowed(j, rs, allowedRes))
-					}
-				}
-			}
-			var headersHTML, pathParamsHTML, queryParamsHTML string
-			if len(headers) > 0 {
-				headersHTML = renderParamInfoTableHTML(headers)
-			}
-			if len(pathsParams) > 0 {
-				pathParamsHTML = renderParamInfoTableHTML(pathsParams)
-			}
-			if len(queryParams) > 0 {
-				queryParamsHTML = renderParamInfoTableHTML(queryParams)
-			}
-			if terr == nil {
-				// 将当前端点数据注入模板片段
-				_ = t.ExecuteTemplate(&bm, "endpoint", EndpointData{
-					Anchor:          anchor,
-					MethodUpper:     strings.ToUpper(m),
-					Summary:         tools.HTMLEscape(summary),
-					Path:            tools.HTMLEscape(p),
-					ContentType:     tools.HTMLEscape(ct),
-					HeadersHTML:     template.HTML(headersHTML),
-					PathParamsHTML:  template.HTML(pathParamsHTML),
-					QueryParamsHTML: template.HTML(queryParamsHTML),
-					ReqExample:      template.HTML(tools.HTMLEscape(reqExample)),
-					ReqTableHTML:    template.HTML(reqTable),
-					ResExample:      template.HTML(htmlEscape(resExample)),
-					ResTableHTML:    template.HTML(resTable),
-				})
-			} else {
-				// 模板不可用时，降级输出空端点占位块
-				bm.WriteString("<div class=\"endpoint\" id=\"" + anchor + "\"></div>")
+		}
+		if terr == nil {
+			childAcc := anchors.group(groupPath(pre, suf))
+			if _, ok := emittedSub[childAcc]; !ok {
+				// 次级分组标题（只输出一次）
+				_ = t.ExecuteTemplate(&bm, "sub_heading", SubHeadingData{Locale: loc, Id: childAcc, Name: cat.label(pre+"/"+suf, suf), Tag: cat.tag(pre + "/" + suf)})
+				emittedSub[childAcc] = true
 			}
 		}
+		ep := buildEndpoint(j, op.Path, op.Method, op.PathItem, op.Op, cfg)
+		ep.Anchor = op.Anchor
+		if terr == nil {
+			// 将当前端点数据注入模板片段
+			_ = t.ExecuteTemplate(&bm, "endpoint", ep)
+		} else {
+			// 模板不可用时，降级输出空端点占位块
+			bm.WriteString("<div class=\"endpoint\" id=\"" + ep.Anchor + "\"></div>")
+		}
 	}
 	if terr != nil {
 		// 模板加载失败时，将导航与正文拼接为最简布局
@@ -351,126 +287,40 @@ func GenerateHTMLWithConfig(j *gjson.Json, contentRaw string, cfg RenderConfig)
 		return fallback.String()
 	}
 	var out strings.Builder
-	_ = t.ExecuteTemplate(&out, "layout", pageData{Title: title, NavHTML: template.HTML(bn.String()), MainHTML: template.HTML(bm.String())})
+	_ = t.ExecuteTemplate(&out, "layout", pageData{Locale: loc, Title: title, NavHTML: template.HTML(bn.String()), MainHTML: template.HTML(bm.String()), AnchorRedirects: template.JS(reg.redirectsJSON())})
 	return out.String()
 }
 
 // GenerateMarkdownWithConfig 与 GenerateMarkdown 一致，但支持 RenderConfig.Customize 的 Header 注入与 Req/Res 过滤。
 func GenerateMarkdownWithConfig(j *gjson.Json, contentRaw string, cfg RenderConfig) string {
-	title := strings.TrimSpace(j.Get("info.title").String())
-	if title == "" {
-		title = "API 文档"
-	}
+	loc := cfg.locale()
 	var b strings.Builder
-	b.WriteString("# " + title + "\n\n")
-	paths := j.GetJsonMap("paths")
-	keys := source.OrderedPathsFromContent(contentRaw)
-	if len(keys) == 0 {
-		for k := range paths {
-			keys = append(keys, k)
+	b.WriteString(renderDocInfoMarkdown(docInfo(j, loc.T("title")), loc))
+	b.WriteString(renderSecuritySchemesMarkdown(securitySchemes(j), loc))
+	cat := loadTagCatalog(j, cfg.groupDelimiter())
+	// 标题层级：文档标题为一级；设置了 x-tagGroups 时其项为二级，分组、子分组与接口依次下移一级
+	level := 2
+	if len(cat.groups) > 0 {
+		level = 3
+	}
+	currTagGroup := ""
+	emittedPre := make(map[string]bool)
+	emittedSub := make(map[string]bool)
+	for i, op := range collectOperations(j, contentRaw, cfg, cat) {
+		pre, suf := op.Pre, op.Suf
+		if len(cat.groups) > 0 && (i == 0 || op.TagGroup != currTagGroup) {
+			currTagGroup = op.TagGroup
+			b.WriteString(mdHeading(2, firstNonEmpty(op.TagGroup, loc.T("other_tags"))))
 		}
-		sortStrings(keys)
-	}
-	groups := make(map[string]map[string][]struct{ P, M string })
-	pfxOrder := make([]string, 0, len(keys))
-	sfxOrder := make(map[string][]string)
-	for _, p := range keys {
-		pj := paths[p]
-		methods := presentMethods(pj)
-		for _, m := range methods {
-			mj := pj.GetJson(m)
-			if mj == nil {
-				continue
-			}
-			tags := tools.JSONArrayStrings(mj.Get("tags").Array())
-			pre, suf := tools.SplitTagParts(tags)
-			if _, ok := groups[pre]; !ok {
-				groups[pre] = make(map[string][]struct{ P, M string })
-				pfxOrder = append(pfxOrder, pre)
-			}
-			if _, ok := groups[pre][suf]; !ok {
-				groups[pre][suf] = make([]struct{ P, M string }, 0, 8)
-				sfxOrder[pre] = append(sfxOrder[pre], suf)
-			}
-			groups[pre][suf] = append(groups[pre][suf], struct{ P, M string }{P: p, M: m})
+		if !emittedPre[pre] {
+			emittedPre[pre] = true
+			b.WriteString(mdHeading(level, cat.label(pre, pre)) + renderTagMarkdown(cat.tag(pre), loc))
 		}
-	}
-	for _, pre := range pfxOrder {
-		b.WriteString("## " + pre + "\n\n")
-		sfx := sfxOrder[pre]
-		for _, suf := range sfx {
-			b.WriteString("### " + suf + "\n\n")
-			items := groups[pre][suf]
-			for _, it := range items {
-				p := it.P
-				m := it.M
-				pj := paths[p]
-				mj := pj.GetJson(m)
-				summary := strings.TrimSpace(mj.Get("summary").String())
-				if summary == "" {
-					summary = strings.ToUpper(m) + " " + p
-				}
-				b.WriteString("#### " + summary + "\n\n")
-				b.WriteString("##### 请求URL\n\n`" + p + "`\n\n")
-				reqSchema, reqCT, reqRef := getRequestSchema(j, mj)
-				resSchema, _, resRef := getResponseSchema(j, mj)
-				ct := reqCT
-				if ct == "" {
-					ct = "application/json"
-				}
-				b.WriteString("##### 请求方式\n\n- " + strings.ToUpper(m) + "  Content-Type: " + ct + "\n\n")
-				headers, pathsParams, queryParams := collectParameters(j, pj, mj)
-				headers = applyCustomizeHeaders(p, headers, cfg)
-				if len(headers) > 0 {
-					b.WriteString("##### Header参数\n\n" + renderParamInfoTableMarkdown(headers) + "\n")
-				}
-				if len(pathsParams) > 0 {
-					b.WriteString("##### 路径参数\n\n" + renderParamInfoTableMarkdown(pathsParams) + "\n")
-				}
-				if len(queryParams) > 0 {
-					b.WriteString("##### Query参数\n\n" + renderParamInfoTableMarkdown(queryParams) + "\n")
-				}
-				allowedReq := cfg.Customize[p].Request
-				if reqSchema != nil {
-					if allowedReq == nil {
-						b.WriteString("##### 请求示例\n\n```json\n" + exampleJSONFromSchema(j, reqSchema) + "\n```\n\n")
-						b.WriteString("##### 请求参数\n\n" + renderParamTableMarkdownFromJson(j, reqSchema) + "\n")
-					} else {
-						b.WriteString("##### 请求示例\n\n```json\n" + exampleJSONFromSchemaWithAllowed(j, reqSchema, allowedReq) + "\n```\n\n")
-						b.WriteString("##### 请求参数\n\n" + renderParamTableMarkdownFromJsonWithAllowed(j, reqSchema, allowedReq) + "\n")
-					}
-				} else if reqRef != "" {
-					if allowedReq == nil {
-						b.WriteString("##### 请求示例\n\n```json\n" + exampleJSON(j, reqRef) + "\n```\n\n")
-						b.WriteString("##### 请求参数\n\n" + renderParamTableMarkdown(j, reqRef) + "\n")
-					} else {
-						b.WriteString("##### 请求示例\n\n```json\n" + exampleJSONWithAllowed(j, reqRef, allowedReq) + "\n```\n\n")
-						b.WriteString("##### 请求参数\n\n" + renderParamTableMarkdownWithAllowed(j, reqRef, allowedReq) + "\n")
-					}
-				}
-				allowedRes := cfg.Customize[p].Response
-				if resSchema != nil {
-					if allowedRes == nil {
-						b.WriteString("##### 返回示例\n\n```json\n" + exampleJSONFromSchema(j, resSchema) + "\n```\n\n")
-						b.WriteString("##### 返回参数说明\n\n" + tools.StripComponentTypeDecorations(renderResponseParamFlatTableMarkdownFromJson(j, resSchema)) + "\n")
-					} else {
-						b.WriteString("##### 返回示例\n\n```json\n" + exampleJSONFromSchemaWithAllowed(j, resSchema, allowedRes) + "\n```\n\n")
-						b.WriteString("##### 返回参数说明\n\n" + tools.StripComponentTypeDecorations(renderResponseParamFlatTableMarkdownFromJsonWithAllowed(j, resSchema, allowedRes)) + "\n")
-					}
-				} else if resRef != "" {
-					if allowedRes == nil {
-						b.WriteString("##### 返回示例\n\n```json\n" + exampleJSON(j, resRef) + "\n```\n\n")
-						b.WriteString("##### 返回参数说明\n\n" + tools.StripComponentTypeDecorations(renderResponseParamFlatTableMarkdownFromRef(j, resRef)) + "\n")
-					} else {
-						rs := getRefJson(j, resRef)
-						if rs != nil {
-							b.WriteString("##### 返回示例\n\n```json\n" + exampleJSONWithAllowed(j, resRef, allowedRes) + "\n```\n\n")
-							b.WriteString("##### 返回参数说明\n\n" + tools.StripComponentTypeDecorations(renderResponseParamFlatTableMarkdownFromJsonWithAllowed(j, rs, allowedRes)) + "\n")
-						}
-					}
-				}
-			}
+		if !emittedSub[pre+"/"+suf] {
+			emittedSub[pre+"/"+suf] = true
+			b.WriteString(mdHeading(level+1, cat.label(pre+"/"+suf, suf)) + renderTagMarkdown(cat.tag(pre+"/"+suf), loc))
 		}
+		renderEndpointMarkdown(&b, buildEndpoint(j, op.Path, op.Method, op.PathItem, op.Op, cfg), level+2)
 	}
 	return b.String()
 }
@@ -480,13 +330,12 @@ func GenerateMarkdown(j *gjson.Json, contentRaw string) string {
 	return GenerateMarkdownWithConfig(j, contentRaw, RenderConfig{})
 }
 
-// presentMethods 返回 path 项中有效的 HTTP 方法列表（小写，排序）。
+// presentMethods 返回 path 项中有效的 HTTP 方法列表（小写，排序）；有效方法与受众裁剪一致（见 isHTTPMethod，含 trace）。
 func presentMethods(pj *gjson.Json) []string {
-	allowed := map[string]struct{}{"get": {}, "post": {}, "put": {}, "delete": {}, "patch": {}, "options": {}, "head": {}}
 	mp := pj.Map()
 	res := make([]string, 0, len(mp))
 	for k := range mp {
-		if _, ok := allowed[strings.ToLower(k)]; ok {
+		if isHTTPMethod(k) {
 			res = append(res, strings.ToLower(k))
 		}
 	}
@@ -495,23 +344,23 @@ func presentMethods(pj *gjson.Json) []string {
 }
 
 // getRequestSchema 提取请求体的 schema（优先 $ref），返回 (schema, contentType, schemaRef)。
-// 说明：遍历 requestBody.content 的媒体类型，记录 contentType；
+// 说明：按 pickMediaType 选择 requestBody.content 的媒体类型（优先 JSON），记录 contentType；
 // - 若 schema.$ref 存在，直接解析引用；
 // - 若为内联 schema，返回该对象。
 func getRequestSchema(j *gjson.Json, op *gjson.Json) (schema *gjson.Json, contentType, schemaRef string) {
-	mp := op.GetJsonMap("requestBody.content")
-	for ct, v := range mp {
-		contentType = ct
-		schemaRef = v.Get("schema.$ref").String()
-		if schemaRef != "" {
-			return getRefJson(j, schemaRef), contentType, schemaRef
-		}
-		s := v.GetJson("schema")
-		if s != nil {
-			return s, contentType, ""
-		}
+	ct, v := pickMediaType(op.GetJsonMap("requestBody.content"))
+	if v == nil {
+		return nil, "", ""
 	}
-	return nil, "", ""
+	contentType = ct
+	schemaRef = v.Get("schema.$ref").String()
+	if schemaRef != "" {
+		return getRefJson(j, schemaRef), contentType, schemaRef
+	}
+	if v.Get("schema").IsNil() {
+		return nil, contentType, ""
+	}
+	return v.GetJson("schema"), contentType, ""
 }
 
 // getResponseSchema 提取响应体的 schema（优先选 200 状态码）。
@@ -521,21 +370,7 @@ func getRequestSchema(j *gjson.Json, op *gjson.Json) (schema *gjson.Json, conten
 // - 返回 (schema, contentType, schemaRef)。
 func getResponseSchema(j *gjson.Json, op *gjson.Json) (schema *gjson.Json, contentType, schemaRef string) {
 	resps := op.GetJsonMap("responses")
-	codes := make([]string, 0, len(resps))
-	for c := range resps {
-		codes = append(codes, c)
-	}
-	sortStrings(codes)
-	pick := ""
-	for _, c := range codes {
-		if c == "200" {
-			pick = c
-			break
-		}
-	}
-	if pick == "" && len(codes) > 0 {
-		pick = codes[0]
-	}
+	pick := primaryResponseCode(op)
 	if pick == "" {
 		return nil, "", ""
 	}
@@ -588,8 +423,25 @@ func getResponseSchema(j *gjson.Json, op *gjson.Json) (schema *gjson.Json, conte
 	return nil, "", ""
 }
 
-// collectParameters 汇总 path+op 层的参数（header/path/query），并解析 $ref。
-func collectParameters(j *gjson.Json, pathItem *gjson.Json, op *gjson.Json) (headers, pathsParams, queryParams []paramInfo) {
+// primaryResponseCode 返回用于主返回展示的状态码：优先 200，否则取排序后的第一个。
+func primaryResponseCode(op *gjson.Json) string {
+	resps := op.GetJsonMap("responses")
+	if _, ok := resps["200"]; ok {
+		return "200"
+	}
+	codes := make([]string, 0, len(resps))
+	for c := range resps {
+		codes = append(codes, c)
+	}
+	sortStrings(codes)
+	if len(codes) == 0 {
+		return ""
+	}
+	return codes[0]
+}
+
+// collectParameters 汇总 path+op 层的参数（header/path/query/cookie），并解析 $ref。
+func collectParameters(j *gjson.Json, pathItem *gjson.Json, op *gjson.Json) (ps endpointParams) {
 	arr := append(pathItem.Get("parameters").Array(), op.Get("parameters").Array()...)
 	for _, v := range arr {
 		pj := gjson.New(v)
@@ -601,27 +453,30 @@ func collectParameters(j *gjson.Json, pathItem *gjson.Json, op *gjson.Json) (hea
 			pj = rp
 		}
 		name := pj.Get("name").String()
-		in := pj.Get("in").String()
-		req := pj.Get("required").Bool()
-		required := "否"
-		if req {
-			required = "是"
-		}
+		in := strings.ToLower(strings.TrimSpace(pj.Get("in").String()))
 		desc := pj.Get("description").String()
-		typ := paramSchemaType(j, pj.GetJson("schema"))
-		info := paramInfo{Name: name, Required: required, Type: typ, Desc: desc}
-		switch in {
-		case "header":
-			headers = append(headers, info)
-		case "path":
-			pathsParams = append(pathsParams, info)
-		case "query":
-			queryParams = append(queryParams, info)
+		sj := pj.GetJson("schema")
+		typ := paramSchemaType(j, sj)
+		info := ParamInfo{Name: name, In: in, Required: pj.Get("required").Bool(), Type: typ, Desc: desc, Constraints: paramConstraints(j, sj), Deprecation: deprecationOf(j, pj)}
+		if IsParamIn(in) {
+			l := ps.list(in)
+			*l = append(*l, info)
 		}
 	}
 	return
 }
 
+// paramConstraints 解析参数 schema 的约束；数组参数取元素约束并合并数组自身的元素数约束。
+func paramConstraints(j *gjson.Json, s *gjson.Json) Constraints {
+	if s == nil || s.IsNil() {
+		return Constraints{}
+	}
+	if schemaTypeName(s) == "array" {
+		return arrayConstraints(j, s, s.GetJson("items"))
+	}
+	return schemaConstraints(j, s)
+}
+
 // paramSchemaType 解析参数 schema 的类型字符串。
 // - 兼容 $ref（显示为 object(<Component>)）
 // - 数组 items 兼容 $ref 与基础类型；对空 items 返回 "array"
@@ -630,9 +485,12 @@ func paramSchemaType(j *gjson.Json, s *gjson.Json) string {
 		return ""
 	}
 	if r := s.Get("$ref").String(); r != "" {
+		if sub := getRefJson(j, r); sub != nil && !isObjectSchema(sub) && schemaTypeName(sub) != "" {
+			return paramSchemaType(j, sub)
+		}
 		return "object"
 	}
-	t := s.Get("type").String()
+	t := schemaTypeName(s)
 	if t == "array" {
 		it := s.GetJson("items")
 		if it != nil {
@@ -0,0 +1,371 @@
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 内置语言代码。
+const (
+	LangZhCN = "zh-CN"
+	LangEn   = "en"
+)
+
+// Locale 文案目录：消息键 → 文本。模板中可通过 {{.Locale.T "request_url"}} 取用。
+type Locale map[string]string
+
+// localeZhCN 简体中文文案（默认语言，也是其他语言缺失键时的回退）。
+var localeZhCN = Locale{
+	"lang":             LangZhCN,
+	"title":            "API 文档",
+	"export_markdown":  "导出为 Markdown",
+	"expand_all":       "全部展开",
+	"collapse_all":     "全部收起",
+	"ungrouped":        "未分组",
+	"default_group":    "默认",
+	"other_tags":       "其他",
+	"request_url":      "请求URL",
+	"request_method":   "请求方式",
+	"headers":          "Header参数",
+	"path_params":      "路径参数",
+	"query_params":     "Query参数",
+	"cookie_params":    "Cookie参数",
+	"request_example":  "请求示例",
+	"request_params":   "请求参数",
+	"response_example": "返回示例",
+	"response_params":  "返回参数说明",
+	"col_name":         "参数名",
+	"col_required":     "必选",
+	"col_type":         "类型",
+	"col_desc":         "说明",
+	"col_field":        "字段",
+	"col_kind":         "类型",
+	"col_endpoint":     "接口",
+	"col_location":     "位置",
+	"col_severity":     "级别",
+	"col_rule":         "规则",
+	"col_constraints":  "约束",
+	"c_enum":           "枚举",
+	"c_format":         "格式",
+	"c_pattern":        "正则",
+	"c_range":          "范围",
+	"c_length":         "长度",
+	"c_items":          "元素数",
+	"c_unique":         "元素唯一",
+	"c_nullable":       "可为 null",
+	"c_default":        "默认值",
+	"c_example":        "示例",
+	"variants_oneOf":   "以下结构任选其一（oneOf）",
+	"variants_anyOf":   "以下结构可任意组合（anyOf）",
+	"variant_at":       "位置",
+	"variant_root":     "（根）",
+	"discriminator":    "区分字段",
+	"info_version":     "版本",
+	"info_contact":     "联系人",
+	"info_license":     "许可",
+	"info_terms":       "服务条款",
+	"external_docs":    "外部文档",
+	"servers_title":    "服务器",
+	"col_url":          "地址",
+	"col_variables":    "变量",
+	"deprecated":       "已废弃",
+	"deprecated_since": "起始版本",
+	"sunset":           "下线日期",
+	"note":             "说明",
+	"warning":          "注意",
+	"auth_title":       "认证方式",
+	"security":         "认证",
+	"auth_public":      "无需认证（公开接口）",
+	"auth_optional":    "可匿名访问",
+	"auth_any_of":      "满足以下任一项即可：",
+	"auth_scopes":      "权限范围",
+	"auth_flow":        "授权流程",
+	"auth_undeclared":  "（未声明的认证方式）",
+	"auth_unknown":     "未知类型",
+	"yes":              "是",
+	"no":               "否",
+	"none":             "无",
+	"no_fields":        "无字段",
+	"diff_title":       "接口变更",
+	"diff_base":        "基线",
+	"diff_target":      "目标",
+	"diff_breaking":    "破坏性变更",
+	"diff_nonbreaking": "非破坏性变更",
+	"diff_added":       "新增",
+	"diff_removed":     "删除",
+	"diff_changed":     "修改",
+	"lint_title":       "文档质量检查",
+	"lint_error":       "错误",
+	"lint_warning":     "警告",
+	"lint_info":        "提示",
+	"lint_clean":       "未发现问题",
+	"d_op_added":       "新增接口",
+	"d_op_removed":     "删除接口",
+	"d_param_opt":      "新增可选参数",
+	"d_param_req":      "新增必填参数",
+	"d_param_removed":  "删除参数",
+	"d_body_added":     "新增请求体",
+	"d_body_removed":   "删除请求体",
+	"d_status_added":   "新增返回状态码",
+	"d_status_removed": "删除返回状态码",
+	"d_field_added":    "新增字段",
+	"d_field_req":      "新增必填字段",
+	"d_field_removed":  "删除字段",
+	"d_type":           "类型由 %s 变为 %s",
+	"d_now_required":   "由可选变为必填",
+	"d_now_optional":   "由必填变为可选",
+	"d_enum_removed":   "移除枚举值 %s",
+	"d_enum_added":     "新增枚举值 %s",
+	"msg_sep":          "；",
+	"l_anchor":         "锚点 %s 与 %s 冲突（文档页中已自动追加后缀，链接可能随接口增删而变化）",
+	"l_dup_opid":       "operationId %s 与 %s 重复",
+	"l_no_summary":     "缺少 summary，将显示为 %s",
+	"l_untagged":       "未设置 tags，将归入默认分组",
+	"l_tag_format":     "tags[%d] \"%s\" 不符合“主/次”分组约定",
+	"l_no_errors":      "未声明 4xx/5xx 或 default 错误响应",
+	"l_param_desc":     "参数 %s 缺少说明",
+	"l_req_desc":       "请求字段 %s 缺少说明",
+	"l_res_desc":       "返回字段 %s 缺少说明",
+	"l_unresolved":     "无法解析引用 %s",
+}
+
+// localeEn 英文文案。
+var localeEn = Locale{
+	"lang":             LangEn,
+	"title":            "API Documentation",
+	"export_markdown":  "Export as Markdown",
+	"expand_all":       "Expand all",
+	"collapse_all":     "Collapse all",
+	"ungrouped":        "Ungrouped",
+	"default_group":    "Default",
+	"other_tags":       "Other",
+	"request_url":      "Request URL",
+	"request_method":   "Method",
+	"headers":          "Header Parameters",
+	"path_params":      "Path Parameters",
+	"query_params":     "Query Parameters",
+	"cookie_params":    "Cookie Parameters",
+	"request_example":  "Request Example",
+	"request_params":   "Request Body",
+	"response_example": "Response Example",
+	"response_params":  "Response Fields",
+	"col_name":         "Name",
+	"col_required":     "Required",
+	"col_type":         "Type",
+	"col_desc":         "Description",
+	"col_field":        "Field",
+	"col_kind":         "Change",
+	"col_endpoint":     "Endpoint",
+	"col_location":     "Location",
+	"col_severity":     "Severity",
+	"col_rule":         "Rule",
+	"col_constraints":  "Constraints",
+	"c_enum":           "enum",
+	"c_format":         "format",
+	"c_pattern":        "pattern",
+	"c_range":          "range",
+	"c_length":         "length",
+	"c_items":          "items",
+	"c_unique":         "unique items",
+	"c_nullable":       "nullable",
+	"c_default":        "default",
+	"c_example":        "example",
+	"variants_oneOf":   "One of the following (oneOf)",
+	"variants_anyOf":   "Any of the following (anyOf)",
+	"variant_at":       "at",
+	"variant_root":     "(root)",
+	"discriminator":    "discriminator",
+	"info_version":     "Version",
+	"info_contact":     "Contact",
+	"info_license":     "License",
+	"info_terms":       "Terms of service",
+	"external_docs":    "External docs",
+	"servers_title":    "Servers",
+	"col_url":          "URL",
+	"col_variables":    "Variables",
+	"deprecated":       "Deprecated",
+	"deprecated_since": "since",
+	"sunset":           "sunset",
+	"note":             "Note",
+	"warning":          "Warning",
+	"auth_title":       "Authentication",
+	"security":         "Authorization",
+	"auth_public":      "No authentication required (public)",
+	"auth_optional":    "Anonymous access allowed",
+	"auth_any_of":      "Any one of the following:",
+	"auth_scopes":      "Scopes",
+	"auth_flow":        "Flow",
+	"auth_undeclared":  "(undeclared scheme)",
+	"auth_unknown":     "unknown type",
+	"yes":              "Yes",
+	"no":               "No",
+	"none":             "None",
+	"no_fields":        "No fields",
+	"diff_title":       "API Changes",
+	"diff_base":        "Base",
+	"diff_target":      "Target",
+	"diff_breaking":    "Breaking changes",
+	"diff_nonbreaking": "Non-breaking changes",
+	"diff_added":       "Added",
+	"diff_removed":     "Removed",
+	"diff_changed":     "Changed",
+	"lint_title":       "Documentation Lint",
+	"lint_error":       "Error",
+	"lint_warning":     "Warning",
+	"lint_info":        "Info",
+	"lint_clean":       "No issues found",
+	"d_op_added":       "Operation added",
+	"d_op_removed":     "Operation removed",
+	"d_param_opt":      "Optional parameter added",
+	"d_param_req":      "Required parameter added",
+	"d_param_removed":  "Parameter removed",
+	"d_body_added":     "Request body added",
+	"d_body_removed":   "Request body removed",
+	"d_status_added":   "Response status added",
+	"d_status_removed": "Response status removed",
+	"d_field_added":    "Field added",
+	"d_field_req":      "Required field added",
+	"d_field_removed":  "Field removed",
+	"d_type":           "Type changed from %s to %s",
+	"d_now_required":   "Changed from optional to required",
+	"d_now_optional":   "Changed from required to optional",
+	"d_enum_removed":   "Enum values removed: %s",
+	"d_enum_added":     "Enum values added: %s",
+	"msg_sep":          "; ",
+	"l_anchor":         "Anchor %s collides with %s (the docs page appends a suffix, so the link may change as operations are added or removed)",
+	"l_dup_opid":       "operationId %s duplicates %s",
+	"l_no_summary":     "Missing summary; shown as %s",
+	"l_untagged":       "No tags; grouped under the default group",
+	"l_tag_format":     "tags[%d] \"%s\" does not follow the \"group/subgroup\" convention",
+	"l_no_errors":      "No 4xx/5xx or default error response declared",
+	"l_param_desc":     "Parameter %s has no description",
+	"l_req_desc":       "Request field %s has no description",
+	"l_res_desc":       "Response field %s has no description",
+	"l_unresolved":     "Cannot resolve reference %s",
+}
+
+// builtinLocales 内置语言目录。
+var builtinLocales = map[string]Locale{LangZhCN: localeZhCN, LangEn: localeEn}
+
+// T 返回消息键对应的文本；缺失时回退到简体中文，再缺失时返回键本身。
+func (l Locale) T(key string) string {
+	if v, ok := l[key]; ok {
+		return v
+	}
+	if v, ok := localeZhCN[key]; ok {
+		return v
+	}
+	return key
+}
+
+// Tf 返回消息键对应的文本，并以 args 填充其中的格式占位符（fmt.Sprintf）。
+func (l Locale) Tf(key string, args ...interface{}) string {
+	return fmt.Sprintf(l.T(key), args...)
+}
+
+// Lang 返回语言代码（用于 <html lang>）。
+func (l Locale) Lang() string {
+	return l.T("lang")
+}
+
+// locale 按 RenderConfig.Lang 解析文案目录：自定义目录（Locales）覆盖同名内置目录的对应键。
+func (cfg RenderConfig) locale() Locale {
+	code := matchLocale(cfg.Lang, cfg.Locales)
+	if code == "" {
+		code = LangZhCN
+	}
+	base := builtinLocales[code]
+	custom, ok := cfg.Locales[code]
+	if !ok {
+		return base
+	}
+	merged := make(Locale, len(base)+len(custom)+1)
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range custom {
+		merged[k] = v
+	}
+	if _, ok := merged["lang"]; !ok {
+		merged["lang"] = code
+	}
+	return merged
+}
+
+// SupportedLangs 返回内置与自定义语言代码（排序后）。
+func SupportedLangs(custom map[string]Locale) []string {
+	set := make(map[string]struct{}, len(builtinLocales)+len(custom))
+	for k := range builtinLocales {
+		set[k] = struct{}{}
+	}
+	for k := range custom {
+		set[k] = struct{}{}
+	}
+	res := make([]string, 0, len(set))
+	for k := range set {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// matchLocale 将语言标签匹配到已有语言代码：先忽略大小写精确匹配，再按主语言匹配（en-US → en，zh → zh-CN）。
+// 无法匹配时返回空串。
+func matchLocale(tag string, custom map[string]Locale) string {
+	tag = strings.TrimSpace(strings.ReplaceAll(tag, "_", "-"))
+	if tag == "" {
+		return ""
+	}
+	langs := SupportedLangs(custom)
+	for _, l := range langs {
+		if strings.EqualFold(l, tag) {
+			return l
+		}
+	}
+	primary := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+	for _, l := range langs {
+		if strings.ToLower(strings.SplitN(l, "-", 2)[0]) == primary {
+			return l
+		}
+	}
+	return ""
+}
+
+// NegotiateLang 确定单次请求的语言：?lang= 优先，其次按 Accept-Language 的 q 值顺序匹配，最后为 def。
+func NegotiateLang(queryLang, acceptLanguage, def string, custom map[string]Locale) string {
+	if l := matchLocale(queryLang, custom); l != "" {
+		return l
+	}
+	type tagQ struct {
+		tag string
+		q   float64
+	}
+	var tags []tagQ
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		if fields[0] == "" || fields[0] == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, tagQ{fields[0], q})
+		}
+	}
+	sort.SliceStable(tags, func(a, b int) bool { return tags[a].q > tags[b].q })
+	for _, t := range tags {
+		if l := matchLocale(t.tag, custom); l != "" {
+			return l
+		}
+	}
+	return def
+}
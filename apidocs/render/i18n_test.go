@@ -0,0 +1,33 @@
+package render
+
+import "testing"
+
+func TestNegotiateLang(t *testing.T) {
+	custom := map[string]Locale{"ja": {"lang": "ja"}, "pt-BR": {"lang": "pt-BR"}}
+	cases := []struct {
+		name, query, accept, def string
+		custom                   map[string]Locale
+		want                     string
+	}{
+		{"default", "", "", LangZhCN, custom, LangZhCN},
+		{"query over header", "en", "zh-CN,zh;q=0.9", LangZhCN, custom, LangEn},
+		{"query case and underscore", "ZH_cn", "en", LangEn, custom, LangZhCN},
+		{"query primary subtag", "en-GB", "", LangZhCN, custom, LangEn},
+		{"unknown query falls back to header", "fr", "en;q=0.8", LangZhCN, custom, LangEn},
+		{"q-values order", "", "zh-CN;q=0.5, en;q=0.9", LangZhCN, custom, LangEn},
+		{"missing q is 1", "", "en;q=0.9, zh-TW", LangEn, custom, LangZhCN},
+		{"q=0 excluded", "", "en;q=0, fr", LangZhCN, custom, LangZhCN},
+		{"wildcard ignored", "", "*, en;q=0.1", LangZhCN, custom, LangEn},
+		{"invalid q treated as 1", "", "fr;q=0.9, en;q=abc", LangZhCN, custom, LangEn},
+		{"unknown only", "", "fr-FR, de;q=0.8", LangEn, custom, LangEn},
+		{"custom locale", "", "ja-JP,en;q=0.5", LangZhCN, custom, "ja"},
+		{"custom locale by query", "pt-br", "en", LangZhCN, custom, "pt-BR"},
+		{"custom locale primary", "", "pt", LangZhCN, custom, "pt-BR"},
+		{"custom locale not configured", "", "ja", LangEn, nil, LangEn},
+	}
+	for _, c := range cases {
+		if got := NegotiateLang(c.query, c.accept, c.def, c.custom); got != c.want {
+			t.Errorf("%s: NegotiateLang(%q, %q, %q) = %q, want %q", c.name, c.query, c.accept, c.def, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// DocInfo 文档头部信息：info、externalDocs 与文档级 servers。
+type DocInfo struct {
+	Title          string
+	Version        string
+	Description    string
+	TermsOfService string
+	ContactName    string
+	ContactURL     string
+	ContactEmail   string
+	LicenseName    string
+	LicenseURL     string
+	ExternalDocs   *ExternalDocInfo
+	Servers        []ServerInfo
+}
+
+// HasMeta 是否有版本之外需要展示的元信息（联系人、许可、服务条款或外部文档）。
+func (d DocInfo) HasMeta() bool {
+	return d.ContactName != "" || d.ContactURL != "" || d.ContactEmail != "" || d.LicenseName != "" ||
+		d.TermsOfService != "" || d.ExternalDocs != nil
+}
+
+// ExternalDocInfo externalDocs 对象。
+type ExternalDocInfo struct {
+	URL         string
+	Description string
+}
+
+// ServerInfo servers 中的一项；URL 为声明的模板（如 https://{env}.example.com/v1），
+// ResolvedURL 为以各变量默认值替换后的地址（去除末尾斜杠）。
+type ServerInfo struct {
+	URL         string
+	ResolvedURL string
+	Description string
+	Variables   []ServerVariable
+}
+
+// ServerVariable 服务器变量：默认值、可选值与说明。
+type ServerVariable struct {
+	Name        string
+	Default     string
+	Enum        []string
+	Description string
+}
+
+// EndpointURL 接口在某个服务器下的完整地址。
+type EndpointURL struct {
+	URL         string
+	Description string
+}
+
+// docInfo 读取文档头部信息；标题缺省时使用 def。
+func docInfo(j *gjson.Json, def string) DocInfo {
+	d := DocInfo{
+		Title:          strings.TrimSpace(j.Get("info.title").String()),
+		Version:        strings.TrimSpace(j.Get("info.version").String()),
+		Description:    strings.TrimSpace(j.Get("info.description").String()),
+		TermsOfService: j.Get("info.termsOfService").String(),
+		ContactName:    j.Get("info.contact.name").String(),
+		ContactURL:     j.Get("info.contact.url").String(),
+		ContactEmail:   j.Get("info.contact.email").String(),
+		LicenseName:    j.Get("info.license.name").String(),
+		LicenseURL:     j.Get("info.license.url").String(),
+		Servers:        parseServers(j.GetJson("servers")),
+	}
+	if d.Title == "" {
+		d.Title = def
+	}
+	if u := j.Get("externalDocs.url").String(); u != "" {
+		d.ExternalDocs = &ExternalDocInfo{URL: u, Description: j.Get("externalDocs.description").String()}
+	}
+	return d
+}
+
+// parseServers 解析 servers 数组，变量按名称排序。
+func parseServers(sj *gjson.Json) []ServerInfo {
+	if sj == nil || sj.IsNil() {
+		return nil
+	}
+	var res []ServerInfo
+	for _, it := range sj.Array() {
+		s := gjson.New(it)
+		info := ServerInfo{URL: s.Get("url").String(), Description: s.Get("description").String()}
+		vm := s.GetJsonMap("variables")
+		names := make([]string, 0, len(vm))
+		for k := range vm {
+			names = append(names, k)
+		}
+		sortStrings(names)
+		resolved := info.URL
+		for _, n := range names {
+			v := ServerVariable{
+				Name:        n,
+				Default:     vm[n].Get("default").String(),
+				Enum:        vm[n].Get("enum").Strings(),
+				Description: vm[n].Get("description").String(),
+			}
+			resolved = strings.ReplaceAll(resolved, "{"+n+"}", v.Default)
+			info.Variables = append(info.Variables, v)
+		}
+		info.ResolvedURL = strings.TrimRight(resolved, "/")
+		res = append(res, info)
+	}
+	return res
+}
+
+// endpointServers 返回接口生效的 servers：接口级优先，其次为路径级，最后为文档级。
+func endpointServers(j *gjson.Json, pathItem *gjson.Json, op *gjson.Json) []ServerInfo {
+	for _, sj := range []*gjson.Json{op.GetJson("servers"), pathItem.GetJson("servers")} {
+		if s := parseServers(sj); len(s) > 0 {
+			return s
+		}
+	}
+	return parseServers(j.GetJson("servers"))
+}
+
+// endpointURLs 拼接接口在各服务器下的完整地址。
+func endpointURLs(servers []ServerInfo, path string) []EndpointURL {
+	res := make([]EndpointURL, 0, len(servers))
+	for _, s := range servers {
+		res = append(res, EndpointURL{URL: s.ResolvedURL + path, Description: s.Description})
+	}
+	return res
+}
+
+// renderDocInfoMarkdown 以 Markdown 渲染文档头部（一级标题、版本、说明、元信息与服务器列表）。
+func renderDocInfoMarkdown(d DocInfo, loc Locale) string {
+	var b strings.Builder
+	b.WriteString("# " + d.Title + "\n\n")
+	if d.Version != "" {
+		b.WriteString(loc.T("info_version") + ": `" + d.Version + "`\n\n")
+	}
+	if d.Description != "" {
+		b.WriteString(d.Description + "\n\n")
+	}
+	var meta []string
+	if d.ContactName != "" || d.ContactURL != "" || d.ContactEmail != "" {
+		c := markdownLink(d.ContactName, d.ContactURL)
+		if d.ContactEmail != "" {
+			c = strings.TrimSpace(c + " <" + d.ContactEmail + ">")
+		}
+		meta = append(meta, loc.T("info_contact")+": "+c)
+	}
+	if d.LicenseName != "" {
+		meta = append(meta, loc.T("info_license")+": "+markdownLink(d.LicenseName, d.LicenseURL))
+	}
+	if d.TermsOfService != "" {
+		meta = append(meta, loc.T("info_terms")+": "+markdownLink("", d.TermsOfService))
+	}
+	if d.ExternalDocs != nil {
+		meta = append(meta, loc.T("external_docs")+": "+markdownLink(d.ExternalDocs.Description, d.ExternalDocs.URL))
+	}
+	for _, m := range meta {
+		b.WriteString("- " + m + "\n")
+	}
+	if len(meta) > 0 {
+		b.WriteString("\n")
+	}
+	if len(d.Servers) > 0 {
+		b.WriteString("## " + loc.T("servers_title") + "\n\n")
+		b.WriteString(tableHeadMarkdown(loc.T("col_url"), loc.T("col_desc"), loc.T("col_variables")))
+		for _, s := range d.Servers {
+			vars := make([]string, 0, len(s.Variables))
+			for _, v := range s.Variables {
+				vars = append(vars, serverVariableText(v, loc))
+			}
+			b.WriteString("| `" + s.URL + "` | " + strings.ReplaceAll(s.Description, "|", "\\|") + " | " + strings.ReplaceAll(strings.Join(vars, "; "), "|", "\\|") + " |\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// serverVariableText 服务器变量的说明文本，如 “{env} 默认值: prod (枚举: prod, dev) 环境”。
+func serverVariableText(v ServerVariable, loc Locale) string {
+	t := "{" + v.Name + "} " + loc.T("c_default") + ": " + v.Default
+	if len(v.Enum) > 0 {
+		t += " (" + loc.T("c_enum") + ": " + strings.Join(v.Enum, ", ") + ")"
+	}
+	if v.Description != "" {
+		t += " " + v.Description
+	}
+	return t
+}
+
+// markdownLink 生成 Markdown 链接；缺少文本时直接显示地址，缺少地址时只输出文本。
+func markdownLink(text, url string) string {
+	switch {
+	case url == "":
+		return text
+	case text == "":
+		return "<" + url + ">"
+	}
+	return "[" + text + "](" + url + ")"
+}
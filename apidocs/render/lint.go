@@ -0,0 +1,387 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/source"
+	"github.com/megatrZlp/go-apidocs/apidocs/tools"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// 文档质量检查规则，用于 LintConfig 的启用/禁用与级别配置。
+const (
+	LintMissingSummary       = "missing-summary"
+	LintMissingDescription   = "missing-description"
+	LintUntagged             = "untagged"
+	LintTagFormat            = "tag-format"
+	LintUnresolvedRef        = "unresolved-ref"
+	LintDuplicateOperationID = "duplicate-operation-id"
+	LintAnchorCollision      = "anchor-collision"
+	LintUndocumentedErrors   = "undocumented-errors"
+)
+
+// 检查结果级别。
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+	LintInfo    = "info"
+)
+
+// lintDefaultSeverity 各规则的默认级别。
+var lintDefaultSeverity = map[string]string{
+	LintUnresolvedRef:        LintError,
+	LintDuplicateOperationID: LintError,
+	LintAnchorCollision:      LintWarning,
+	LintMissingSummary:       LintWarning,
+	LintUntagged:             LintWarning,
+	LintUndocumentedErrors:   LintWarning,
+	LintTagFormat:            LintInfo,
+	LintMissingDescription:   LintInfo,
+}
+
+// LintRules 返回所有内置规则名（按报告顺序）。
+func LintRules() []string {
+	return []string{LintUnresolvedRef, LintDuplicateOperationID, LintAnchorCollision, LintMissingSummary, LintUntagged, LintUndocumentedErrors, LintTagFormat, LintMissingDescription}
+}
+
+// LintConfig 文档质量检查配置。
+// - Disabled: 禁用的规则名
+// - Severity: 覆盖规则级别（error/warning/info），未配置时使用默认级别
+type LintConfig struct {
+	Disabled []string
+	Severity map[string]string
+}
+
+// LintFinding 单条检查结果；Anchor 为接口在文档页中的锚点（文档级问题为空）。
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Method   string `json:"method,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Anchor   string `json:"anchor,omitempty"`
+	Message  string `json:"message"`
+}
+
+// LintReport 文档质量检查报告。
+type LintReport struct {
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+	Infos    int           `json:"infos"`
+	Findings []LintFinding `json:"findings"`
+}
+
+// linter 持有规则配置并收集检查结果。
+type linter struct {
+	disabled map[string]bool
+	severity map[string]string
+	rep      LintReport
+	// loc 生成检查说明所用的语言包
+	loc Locale
+}
+
+func (l *linter) report(f LintFinding) {
+	if l.disabled[f.Rule] {
+		return
+	}
+	f.Severity = lintDefaultSeverity[f.Rule]
+	if s, ok := l.severity[f.Rule]; ok && s != "" {
+		f.Severity = s
+	}
+	f.Method = strings.ToUpper(f.Method)
+	switch f.Severity {
+	case LintError:
+		l.rep.Errors++
+	case LintWarning:
+		l.rep.Warnings++
+	default:
+		l.rep.Infos++
+	}
+	l.rep.Findings = append(l.rep.Findings, f)
+}
+
+// LintSpec 按配置的规则检查文档质量：缺失摘要/说明、未打标签或标签不符合“主/次”约定、
+// 无法解析的 $ref、重复的 operationId、锚点冲突以及未声明错误响应的接口。
+// 结果按 paths 原始顺序排列，文档级问题（无法解析的 $ref 等）排在最前；检查说明使用默认语言（zh-CN）。
+func LintSpec(j *gjson.Json, contentRaw string, cfg LintConfig) LintReport {
+	return LintSpecWithConfig(j, contentRaw, cfg, RenderConfig{})
+}
+
+// LintSpecWithConfig 同 LintSpec，检查说明按 rc.Lang 生成；标签格式按 rc 的分组策略与 GroupDelimiter 检查：
+// 按 tags 分组时检查每个标签，按 path/x-group 或 GroupFunc 分组时不检查。
+func LintSpecWithConfig(j *gjson.Json, contentRaw string, cfg LintConfig, rc RenderConfig) LintReport {
+	l := &linter{disabled: map[string]bool{}, severity: cfg.Severity, rep: LintReport{Findings: []LintFinding{}}, loc: rc.locale()}
+	for _, r := range cfg.Disabled {
+		l.disabled[r] = true
+	}
+	if j == nil {
+		return l.rep
+	}
+	// 锚点与文档页使用同一次分配（相同的可见接口、分组与登记顺序），冲突即基础锚点被其他接口或分组占用
+	pa := docAnchors(j, contentRaw, rc)
+	owners := make(map[string]string, len(pa.ops)+len(pa.groups)+len(pa.tagGroups))
+	for name, id := range pa.tagGroups {
+		owners[id] = name
+	}
+	for path, id := range pa.groups {
+		owners[id] = path
+	}
+	for key, id := range pa.ops {
+		owners[id] = key
+	}
+	lintRefs(l, j, pa)
+	paths := j.GetJsonMap("paths")
+	keys := source.OrderedPathsFromContent(contentRaw)
+	if len(keys) == 0 {
+		for k := range paths {
+			keys = append(keys, k)
+		}
+		sortStrings(keys)
+	}
+	// 标签格式仅在按标签分组时检查（GroupFunc 或 path/x-group 分组不使用标签层级），层级分隔符与文档页一致
+	groupBy := strings.ToLower(rc.GroupBy)
+	byTags := groupBy == GroupByTags
+	tagGrouped := rc.GroupFunc == nil && (groupBy == "" || groupBy == GroupByTag || byTags)
+	delim := rc.groupDelimiter()
+	opIDs := make(map[string]string)
+	for _, p := range keys {
+		pj := paths[p]
+		if pj == nil {
+			continue
+		}
+		for _, m := range presentMethods(pj) {
+			mj := pj.GetJson(m)
+			// 与文档页一致：隐藏的接口（Customize.Hide、HideDeprecated）不检查
+			if mj == nil || rc.hidesOperation(j, mj, customizeFor(p, m, mj, rc)) {
+				continue
+			}
+			where := strings.ToUpper(m) + " " + p
+			at := LintFinding{Method: m, Path: p, Anchor: pa.ops[where]}
+			if base := pa.bases[where]; at.Anchor != base {
+				f := at
+				f.Rule, f.Message = LintAnchorCollision, l.loc.Tf("l_anchor", base, firstNonEmpty(owners[base], base))
+				l.report(f)
+			}
+			if id := strings.TrimSpace(mj.Get("operationId").String()); id != "" {
+				if prev, ok := opIDs[id]; ok {
+					f := at
+					f.Rule, f.Message = LintDuplicateOperationID, l.loc.Tf("l_dup_opid", id, prev)
+					l.report(f)
+				} else {
+					opIDs[id] = where
+				}
+			}
+			if strings.TrimSpace(mj.Get("summary").String()) == "" {
+				f := at
+				f.Rule, f.Message = LintMissingSummary, l.loc.Tf("l_no_summary", where)
+				l.report(f)
+			}
+			tags := jsonArrayStrings(mj.Get("tags").Array())
+			if len(tags) == 0 {
+				f := at
+				f.Rule, f.Message = LintUntagged, l.loc.T("l_untagged")
+				l.report(f)
+			} else if tagGrouped {
+				checked := tags[:1]
+				if byTags {
+					checked = tags
+				}
+				for i, tag := range checked {
+					if len(tools.SplitGroupPath(tag, delim)) < 2 {
+						f := at
+						f.Rule, f.Message = LintTagFormat, l.loc.Tf("l_tag_format", i, tag)
+						l.report(f)
+					}
+				}
+			}
+			if !hasErrorResponse(mj) {
+				f := at
+				f.Rule, f.Message = LintUndocumentedErrors, l.loc.T("l_no_errors")
+				l.report(f)
+			}
+			lintDescriptions(l, j, pj, mj, at)
+		}
+	}
+	return l.rep
+}
+
+// hasErrorResponse 判断接口是否声明了 4xx/5xx 或 default 响应。
+func hasErrorResponse(op *gjson.Json) bool {
+	for code := range op.GetJsonMap("responses") {
+		if code == "default" || strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5") {
+			return true
+		}
+	}
+	return false
+}
+
+// lintDescriptions 检查参数与请求/返回字段的说明是否为空（说明取 title 与 description 的组合）。
+func lintDescriptions(l *linter, j *gjson.Json, pathItem, op *gjson.Json, at LintFinding) {
+	ps := collectParameters(j, pathItem, op)
+	for _, group := range [][]ParamInfo{ps.Headers, ps.Path, ps.Query, ps.Cookie} {
+		for _, p := range group {
+			if strings.TrimSpace(p.Desc) == "" {
+				f := at
+				f.Rule, f.Field, f.Message = LintMissingDescription, p.Name, l.loc.Tf("l_param_desc", p.Name)
+				l.report(f)
+			}
+		}
+	}
+	if reqSchema, _, _ := getRequestSchema(j, op); reqSchema != nil {
+		for _, fi := range flattenSchemaFieldsFromJson(j, reqSchema, "", viewRequest) {
+			if strings.TrimSpace(fi.Desc) == "" {
+				f := at
+				f.Rule, f.Field, f.Message = LintMissingDescription, fi.Path, l.loc.Tf("l_req_desc", fi.Path)
+				l.report(f)
+			}
+		}
+	}
+	if resSchema, _, _ := getResponseSchema(j, op); resSchema != nil {
+		for _, fi := range flattenSchemaFieldsFromJson(j, resSchema, "", viewResponse) {
+			if strings.TrimSpace(fi.Desc) == "" {
+				f := at
+				f.Rule, f.Field, f.Message = LintMissingDescription, fi.Path, l.loc.Tf("l_res_desc", fi.Path)
+				l.report(f)
+			}
+		}
+	}
+}
+
+// lintRefs 遍历整个文档，报告无法在本文档内解析的 "#/" 引用；位于某个接口下时附带接口位置与其在文档页中的锚点（接口隐藏时为空）。
+func lintRefs(l *linter, j *gjson.Json, pa pageAnchors) {
+	root := j.Interface()
+	var walk func(v interface{}, trail []string)
+	walk = func(v interface{}, trail []string) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			if ref, ok := t["$ref"].(string); ok && strings.HasPrefix(ref, "#/") {
+				if _, found := resolvePointer(root, ref); !found {
+					f := LintFinding{Rule: LintUnresolvedRef, Field: strings.Join(trail, "."), Message: l.loc.Tf("l_unresolved", ref)}
+					if len(trail) >= 3 && trail[0] == "paths" {
+						f.Path, f.Method = trail[1], trail[2]
+						f.Anchor = pa.ops[strings.ToUpper(trail[2])+" "+trail[1]]
+						f.Field = strings.Join(trail[3:], ".")
+					}
+					l.report(f)
+				}
+			}
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			sortStrings(keys)
+			for _, k := range keys {
+				walk(t[k], append(trail, k))
+			}
+		case []interface{}:
+			for i, it := range t {
+				walk(it, append(trail, fmt.Sprintf("%d", i)))
+			}
+		}
+	}
+	walk(root, nil)
+}
+
+// resolvePointer 按 JSON Pointer（#/a/b，支持 ~0/~1 转义）在文档树中查找节点。
+func resolvePointer(root interface{}, ref string) (interface{}, bool) {
+	cur := root
+	for _, seg := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		seg = strings.ReplaceAll(strings.ReplaceAll(seg, "~1", "/"), "~0", "~")
+		switch t := cur.(type) {
+		case map[string]interface{}:
+			v, ok := t[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []interface{}:
+			var i int
+			if _, err := fmt.Sscanf(seg, "%d", &i); err != nil || i < 0 || i >= len(t) {
+				return nil, false
+			}
+			cur = t[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// lintSeverityLabel 级别的展示文本。
+func lintSeverityLabel(s string, loc Locale) string {
+	switch s {
+	case LintError:
+		return loc.T("lint_error")
+	case LintWarning:
+		return loc.T("lint_warning")
+	}
+	return loc.T("lint_info")
+}
+
+// RenderLintMarkdown 将检查报告渲染为 Markdown 片段；标题与表头按 cfg.Lang 取值，检查说明在生成报告时已按语言生成（见 LintSpecWithConfig）。
+func RenderLintMarkdown(r LintReport, cfg RenderConfig) string {
+	loc := cfg.locale()
+	var b strings.Builder
+	b.WriteString("## " + loc.T("lint_title") + "\n\n")
+	b.WriteString(fmt.Sprintf("- %s: %d\n- %s: %d\n- %s: %d\n\n", loc.T("lint_error"), r.Errors, loc.T("lint_warning"), r.Warnings, loc.T("lint_info"), r.Infos))
+	b.WriteString("| " + loc.T("col_severity") + " | " + loc.T("col_rule") + " | " + loc.T("col_endpoint") + " | " + loc.T("col_location") + " | " + loc.T("col_desc") + " |\n|---|---|---|---|---|\n")
+	if len(r.Findings) == 0 {
+		b.WriteString("| " + loc.T("none") + " |  |  |  |  |\n")
+	}
+	for _, f := range r.Findings {
+		ep := ""
+		if f.Path != "" {
+			ep = "`" + f.Method + " " + f.Path + "`"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", lintSeverityLabel(f.Severity, loc), f.Rule, ep, f.Field, f.Message))
+	}
+	return b.String()
+}
+
+// GenerateLintHTML 将检查报告渲染为完整 HTML 页面（复用文档页布局）；每条结果链接到文档页中接口的锚点。
+func GenerateLintHTML(r LintReport, docsRoute string, cfg RenderConfig) string {
+	loc := cfg.locale()
+	var main strings.Builder
+	main.WriteString("<h1>" + loc.T("lint_title") + "</h1>")
+	main.WriteString(fmt.Sprintf("<ul><li>%s: %d</li><li>%s: %d</li><li>%s: %d</li></ul>", loc.T("lint_error"), r.Errors, loc.T("lint_warning"), r.Warnings, loc.T("lint_info"), r.Infos))
+	groups := make([]*NavGroupVM, 0, len(LintRules()))
+	for _, rule := range LintRules() {
+		var rows strings.Builder
+		n := 0
+		for _, f := range r.Findings {
+			if f.Rule != rule {
+				continue
+			}
+			n++
+			ep := htmlEscape(f.Method + " " + f.Path)
+			if f.Anchor != "" {
+				ep = "<a href=\"" + htmlEscape(docsRoute) + "#" + f.Anchor + "\">" + ep + "</a>"
+			}
+			rows.WriteString("<tr><td>" + lintSeverityLabel(f.Severity, loc) + "</td><td>" + ep + "</td><td>" + htmlEscape(f.Field) + "</td><td>" + htmlEscape(f.Message) + "</td></tr>")
+		}
+		if n == 0 {
+			continue
+		}
+		id := "lint-" + rule
+		groups = append(groups, &NavGroupVM{Name: fmt.Sprintf("%s (%d)", rule, n), Id: id})
+		main.WriteString("<h2 id=\"" + id + "\">" + htmlEscape(rule) + "</h2>")
+		main.WriteString("<table><thead><tr><th>" + loc.T("col_severity") + "</th><th>" + loc.T("col_endpoint") + "</th><th>" + loc.T("col_location") + "</th><th>" + loc.T("col_desc") + "</th></tr></thead><tbody>")
+		main.WriteString(rows.String())
+		main.WriteString("</tbody></table>")
+	}
+	if len(r.Findings) == 0 {
+		main.WriteString("<p>" + loc.T("lint_clean") + "</p>")
+	}
+	t, err := buildLayoutTemplate(cfg)
+	if err != nil {
+		return "<div class=\"layout\"><aside></aside><main>" + main.String() + "</main></div>"
+	}
+	var nav strings.Builder
+	_ = t.ExecuteTemplate(&nav, "nav", NavData{Locale: loc, Groups: groups})
+	var out strings.Builder
+	_ = t.ExecuteTemplate(&out, "layout", pageData{Locale: loc, Title: loc.T("lint_title"), NavHTML: template.HTML(nav.String()), MainHTML: template.HTML(main.String())})
+	return out.String()
+}
@@ -0,0 +1,216 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// lintSpec 覆盖全部规则：/a/{id} 与 /a/id 锚点冲突、重复 operationId、缺少 summary/tags/说明、标签格式、无错误响应与无法解析的引用。
+const lintSpec = `{"paths":{
+	"/a/{id}":{"get":{"operationId":"getA","summary":"A","tags":["users/read"],"responses":{"404":{"description":"x"}}}},
+	"/a/id":{"get":{"operationId":"getA","tags":["users"],"parameters":[{"name":"q","in":"query"}],"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Missing"}}}}}}},
+	"/b":{"post":{"summary":"B","responses":{"default":{"description":"x"}}}}}}`
+
+func TestLintSpecEnglish(t *testing.T) {
+	j := gjson.New(lintSpec)
+	cfg := RenderConfig{Lang: LangEn}
+	rep := LintSpecWithConfig(j, lintSpec, LintConfig{}, cfg)
+	want := map[string]string{
+		LintUnresolvedRef:        "Cannot resolve reference #/components/schemas/Missing",
+		LintAnchorCollision:      "Anchor ",
+		LintDuplicateOperationID: "operationId getA duplicates GET /a/{id}",
+		LintMissingSummary:       "Missing summary; shown as GET /a/id",
+		LintTagFormat:            `tags[0] "users" does not follow the "group/subgroup" convention`,
+		LintUndocumentedErrors:   "No 4xx/5xx or default error response declared",
+		LintUntagged:             "No tags; grouped under the default group",
+		LintMissingDescription:   "Parameter q has no description",
+	}
+	seen := map[string]bool{}
+	for _, f := range rep.Findings {
+		if r := nonASCII(f.Message); r != 0 {
+			t.Errorf("%s message %q contains %q", f.Rule, f.Message, r)
+		}
+		if !seen[f.Rule] && !strings.HasPrefix(f.Message, want[f.Rule]) {
+			t.Errorf("%s message = %q, want prefix %q", f.Rule, f.Message, want[f.Rule])
+		}
+		seen[f.Rule] = true
+	}
+	for rule := range want {
+		if !seen[rule] {
+			t.Errorf("rule %s not reported", rule)
+		}
+	}
+	if r := nonASCII(RenderLintMarkdown(rep, cfg)); r != 0 {
+		t.Errorf("english markdown contains %q", r)
+	}
+	if zh := LintSpec(j, lintSpec, LintConfig{}); zh.Findings[0].Message != "无法解析引用 #/components/schemas/Missing" {
+		t.Errorf("default language message = %q", zh.Findings[0].Message)
+	}
+}
+
+func TestLintTagFormatFollowsGrouping(t *testing.T) {
+	spec := `{"paths":{"/a":{"get":{"tags":["users.read","users/read"]}}}}`
+	j := gjson.New(spec)
+	cases := []struct {
+		name string
+		cfg  RenderConfig
+		want []string
+	}{
+		{"default delimiter", RenderConfig{}, []string{"users.read"}},
+		{"custom delimiter", RenderConfig{GroupDelimiter: "."}, nil},
+		{"all tags", RenderConfig{GroupBy: GroupByTags, GroupDelimiter: "."}, []string{"users/read"}},
+		{"all tags default delimiter", RenderConfig{GroupBy: GroupByTags}, []string{"users.read"}},
+		{"path grouping", RenderConfig{GroupBy: GroupByPath}, nil},
+		{"x-group grouping", RenderConfig{GroupBy: GroupByExtension}, nil},
+		{"group func", RenderConfig{GroupFunc: func(string, string, *gjson.Json) [][]string { return nil }}, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rep := LintSpecWithConfig(j, spec, LintConfig{}, c.cfg)
+			var got []string
+			for _, f := range rep.Findings {
+				if f.Rule == LintTagFormat {
+					got = append(got, f.Message)
+				}
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("tag-format findings = %q, want tags %q", got, c.want)
+			}
+			for i, tag := range c.want {
+				if !strings.Contains(got[i], `"`+tag+`"`) {
+					t.Errorf("finding %q, want tag %q", got[i], tag)
+				}
+			}
+		})
+	}
+}
+
+func TestLintAnchorsMatchDocsPage(t *testing.T) {
+	spec := `{"paths":{
+		"/a/{id}":{"get":{"operationId":"getA","deprecated":true,"responses":{"200":{}}}},
+		"/a/id":{"get":{"operationId":"listA","responses":{"200":{}}}},
+		"/b":{"get":{"operationId":"getA","responses":{"200":{}}}}}}`
+	j := gjson.New(spec)
+	anchorsOf := func(rep LintReport, rule string) []string {
+		var res []string
+		for _, f := range rep.Findings {
+			if f.Rule == rule {
+				res = append(res, f.Method+" "+f.Path+" #"+f.Anchor)
+			}
+		}
+		return res
+	}
+	cases := []struct {
+		name       string
+		cfg        RenderConfig
+		collisions []string
+		summaries  []string
+	}{
+		{"method and path", RenderConfig{}, []string{"GET /a/id #get-a-id-2"}, []string{"GET /a/{id} #get-a-id", "GET /a/id #get-a-id-2", "GET /b #get-b"}},
+		{"operationId", RenderConfig{AnchorOperationID: true}, []string{"GET /b #getA-2"}, []string{"GET /a/{id} #getA", "GET /a/id #listA", "GET /b #getA-2"}},
+		{"hidden operation", RenderConfig{HideDeprecated: true}, nil, []string{"GET /a/id #get-a-id", "GET /b #get-b"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rep := LintSpecWithConfig(j, spec, LintConfig{}, c.cfg)
+			if got := anchorsOf(rep, LintAnchorCollision); strings.Join(got, ",") != strings.Join(c.collisions, ",") {
+				t.Errorf("collisions = %q, want %q", got, c.collisions)
+			}
+			got := anchorsOf(rep, LintMissingSummary)
+			if strings.Join(got, ",") != strings.Join(c.summaries, ",") {
+				t.Errorf("anchors = %q, want %q", got, c.summaries)
+			}
+			page := GenerateHTMLWithConfig(j, spec, c.cfg)
+			for _, s := range got {
+				if id := s[strings.Index(s, "#")+1:]; !strings.Contains(page, `id="`+id+`"`) {
+					t.Errorf("docs page has no element with id %q", id)
+				}
+			}
+		})
+	}
+}
+
+func TestLintSpecRules(t *testing.T) {
+	j := gjson.New(lintSpec)
+	describe := func(rep LintReport) string {
+		var res []string
+		for _, f := range rep.Findings {
+			res = append(res, strings.TrimSpace(f.Rule+" "+f.Severity+" "+f.Method+" "+f.Path+" "+f.Field))
+		}
+		return strings.Join(res, "\n")
+	}
+	cases := []struct {
+		name                   string
+		cfg                    LintConfig
+		want                   []string
+		errors, warnings, info int
+	}{
+		{
+			name: "defaults",
+			want: []string{
+				"unresolved-ref error GET /a/id responses.200.content.application/json.schema",
+				"anchor-collision warning GET /a/id",
+				"duplicate-operation-id error GET /a/id",
+				"missing-summary warning GET /a/id",
+				"tag-format info GET /a/id",
+				"undocumented-errors warning GET /a/id",
+				"missing-description info GET /a/id q",
+				"untagged warning POST /b",
+			},
+			errors: 2, warnings: 4, info: 2,
+		},
+		{
+			name: "disabled and severity overrides",
+			cfg: LintConfig{
+				Disabled: []string{LintAnchorCollision, LintMissingDescription, LintUnresolvedRef},
+				Severity: map[string]string{LintUntagged: LintError, LintTagFormat: LintWarning, LintMissingSummary: ""},
+			},
+			want: []string{
+				"duplicate-operation-id error GET /a/id",
+				"missing-summary warning GET /a/id",
+				"tag-format warning GET /a/id",
+				"undocumented-errors warning GET /a/id",
+				"untagged error POST /b",
+			},
+			errors: 2, warnings: 3,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rep := LintSpec(j, lintSpec, c.cfg)
+			if got := describe(rep); got != strings.Join(c.want, "\n") {
+				t.Errorf("findings:\n%s\nwant:\n%s", got, strings.Join(c.want, "\n"))
+			}
+			if rep.Errors != c.errors || rep.Warnings != c.warnings || rep.Infos != c.info {
+				t.Errorf("counts = %d/%d/%d, want %d/%d/%d", rep.Errors, rep.Warnings, rep.Infos, c.errors, c.warnings, c.info)
+			}
+		})
+	}
+	if rep := LintSpec(nil, "", LintConfig{}); len(rep.Findings) != 0 || rep.Findings == nil {
+		t.Errorf("nil spec = %+v, want an empty report", rep)
+	}
+	if len(LintRules()) != len(lintDefaultSeverity) {
+		t.Errorf("LintRules and lintDefaultSeverity are out of sync")
+	}
+}
+
+func TestLintOutputs(t *testing.T) {
+	rep := LintSpec(gjson.New(lintSpec), lintSpec, LintConfig{})
+	md := RenderLintMarkdown(rep, RenderConfig{})
+	for _, s := range []string{"## ", "duplicate-operation-id", "`GET /a/id`"} {
+		if !strings.Contains(md, s) {
+			t.Errorf("markdown missing %q:\n%s", s, md)
+		}
+	}
+	page := GenerateLintHTML(rep, "/docs", RenderConfig{})
+	for _, s := range []string{`id="lint-unresolved-ref"`, `href="/docs#get-a-id-2"`, "untagged (1)"} {
+		if !strings.Contains(page, s) {
+			t.Errorf("html missing %q", s)
+		}
+	}
+	if page := GenerateLintHTML(LintReport{}, "/docs", RenderConfig{Lang: LangEn}); !strings.Contains(page, "No issues found") {
+		t.Errorf("clean report does not say so")
+	}
+}
@@ -0,0 +1,114 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// mapEntry 映射类型对象（additionalProperties/patternProperties）中的一类值。
+// - Segment: 字段路径中的键段，additionalProperties 为 {key}，patternProperties 为 {key:<pattern>}
+// - Pattern: patternProperties 的键正则，additionalProperties 为空串
+type mapEntry struct {
+	Segment string
+	Pattern string
+	Schema  *gjson.Json
+}
+
+// mapEntries 返回 schema 上的映射值定义：additionalProperties（schema 或 true）在前，patternProperties 按正则排序在后。
+// additionalProperties 为 false 或未声明时不输出。
+func mapEntries(s *gjson.Json) []mapEntry {
+	if s == nil {
+		return nil
+	}
+	var res []mapEntry
+	switch ap := s.Get("additionalProperties"); {
+	case ap.IsMap():
+		res = append(res, mapEntry{Segment: "{key}", Schema: s.GetJson("additionalProperties")})
+	case ap.Val() == true:
+		res = append(res, mapEntry{Segment: "{key}", Schema: gjson.New(map[string]interface{}{})})
+	}
+	pm := s.GetJsonMap("patternProperties")
+	patterns := make([]string, 0, len(pm))
+	for p := range pm {
+		patterns = append(patterns, p)
+	}
+	sortStrings(patterns)
+	for _, p := range patterns {
+		res = append(res, mapEntry{Segment: "{key:" + p + "}", Pattern: p, Schema: pm[p]})
+	}
+	return res
+}
+
+// mapTypeName 映射类型的展示名，如 map<string, Price>；多类值类型不同时以 | 连接。不是映射类型时返回空串。
+func mapTypeName(j *gjson.Json, s *gjson.Json) string {
+	entries := mapEntries(s)
+	if len(entries) == 0 {
+		return ""
+	}
+	var names []string
+	for _, e := range entries {
+		n := mapValueTypeName(j, e.Schema)
+		dup := false
+		for _, x := range names {
+			dup = dup || x == n
+		}
+		if !dup {
+			names = append(names, n)
+		}
+	}
+	return "map<string, " + strings.Join(names, " | ") + ">"
+}
+
+// mapValueTypeName 映射值的类型名：引用对象组件取组件名，引用基础类型取其类型，未声明类型为 any。
+func mapValueTypeName(j *gjson.Json, s *gjson.Json) string {
+	if r := s.Get("$ref").String(); r != "" {
+		if isRefToObject(j, r) {
+			return componentNameFromRef(r)
+		}
+		return leafTypeName(j, s)
+	}
+	if m := mapTypeName(j, s); m != "" && len(s.GetJsonMap("properties")) == 0 {
+		return m
+	}
+	switch t := schemaTypeName(s); {
+	case t == "array":
+		it := s.GetJson("items")
+		if it == nil || it.IsNil() {
+			return "array"
+		}
+		return "array(" + mapValueTypeName(j, it) + ")"
+	case t != "":
+		return t
+	case isObjectSchema(s):
+		return "object"
+	}
+	return "any"
+}
+
+// objectArrayType 对象数组行的类型展示：元素为映射时为 array(map<string, X>)，其余为 array(object)。
+func objectArrayType(j *gjson.Json, it *gjson.Json) string {
+	if r := it.Get("$ref").String(); r != "" {
+		it = getRefJson(j, r)
+	}
+	if m := mapTypeName(j, it); m != "" && len(mergedProperties(j, it)) == 0 {
+		return "array(" + m + ")"
+	}
+	return "array(object)"
+}
+
+// sampleMapKey 示例中映射的样例键：优先 propertyNames 的 example 与首个枚举值，否则为 key；
+// 存在多类值时追加序号区分（key1、key2）。
+func sampleMapKey(s *gjson.Json, i, n int) string {
+	key := "key"
+	if v := s.Get("propertyNames.example").String(); v != "" {
+		key = v
+	} else if e := s.Get("propertyNames.enum").Strings(); len(e) > 0 {
+		key = e[0]
+	}
+	if n > 1 {
+		key += strconv.Itoa(i + 1)
+	}
+	return key
+}
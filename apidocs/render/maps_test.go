@@ -0,0 +1,69 @@
+package render
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+const mapSpec = `{"paths":{"/prices":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"type":"object","properties":{
+	"prices":{"type":"object","additionalProperties":{"$ref":"#/components/schemas/Price"},"propertyNames":{"example":"USD"}},
+	"labels":{"type":"object","additionalProperties":true},
+	"counts":{"type":"object","additionalProperties":{"type":"integer","default":1},"patternProperties":{"^x-":{"type":"string","default":"v"}}},
+	"closed":{"type":"object","additionalProperties":false,"properties":{"a":{"type":"string"}}},
+	"matrix":{"type":"array","items":{"type":"object","additionalProperties":{"type":"array","items":{"type":"number"}}}}}}}}}}}}},
+	"components":{"schemas":{"Price":{"type":"object","properties":{"amount":{"type":"number","default":1.5}}}}}}`
+
+func TestMapFieldRows(t *testing.T) {
+	j := gjson.New(mapSpec)
+	pj := j.GetJsonMap("paths")["/prices"]
+	ep := buildEndpoint(j, "/prices", "get", pj, pj.GetJson("get"), RenderConfig{})
+	var rows []string
+	for _, f := range ep.ResponseFields {
+		rows = append(rows, f.Path+" "+f.Type)
+	}
+	want := []string{
+		"closed object",
+		"closed.a string",
+		"counts map<string, integer | string>",
+		"counts.{key} integer",
+		"counts.{key:^x-} string",
+		"labels map<string, any>",
+		"matrix[] array(map<string, array(number)>)",
+		"matrix[].{key}[] array(number)",
+		"prices map<string, Price>",
+		"prices.{key} object",
+		"prices.{key}.amount number",
+	}
+	if strings.Join(rows, "\n") != strings.Join(want, "\n") {
+		t.Errorf("rows:\n%s\nwant:\n%s", strings.Join(rows, "\n"), strings.Join(want, "\n"))
+	}
+	if !strings.Contains(string(ep.ResTableHTML), "map&lt;string, Price&gt;") {
+		t.Errorf("html table must escape map types")
+	}
+}
+
+func TestMapExamples(t *testing.T) {
+	j := gjson.New(mapSpec)
+	pj := j.GetJsonMap("paths")["/prices"]
+	ep := buildEndpoint(j, "/prices", "get", pj, pj.GetJson("get"), RenderConfig{})
+	var ex map[string]interface{}
+	if err := json.Unmarshal([]byte(ep.ResponseExample), &ex); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"closed": map[string]interface{}{"a": "string"},
+		// 多类值时样例键追加序号
+		"counts": map[string]interface{}{"key1": float64(1), "key2": "v"},
+		"labels": map[string]interface{}{},
+		"matrix": []interface{}{map[string]interface{}{"key": []interface{}{}}},
+		// propertyNames.example 作为样例键
+		"prices": map[string]interface{}{"USD": map[string]interface{}{"amount": 1.5}},
+	}
+	if !reflect.DeepEqual(ex, want) {
+		t.Errorf("example = %v\nwant %v", ex, want)
+	}
+}
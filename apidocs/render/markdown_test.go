@@ -0,0 +1,71 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+var headingLineRe = regexp.MustCompile(`(?m)^(#{1,6}) (.+)$`)
+
+// headings 返回 Markdown 中按出现顺序排列的标题（“## 名称”形式），只保留 names 中列出的名称，便于忽略接口小节。
+func headings(md string, names ...string) []string {
+	keep := map[string]bool{}
+	for _, n := range names {
+		keep[n] = true
+	}
+	var res []string
+	for _, m := range headingLineRe.FindAllStringSubmatch(md, -1) {
+		if keep[m[2]] {
+			res = append(res, m[1]+" "+m[2])
+		}
+	}
+	return res
+}
+
+const markdownOrderSpec = `{"info":{"title":"Docs"},
+	"tags":[{"name":"orders"},{"name":"users","x-displayName":"Users"},{"name":"users/admin"}],
+	"paths":{
+		"/misc":{"get":{"summary":"Misc","tags":["misc/x"]}},
+		"/users":{"get":{"summary":"List users","tags":["users/admin"]}},
+		"/orders":{"get":{"summary":"List orders","tags":["orders/query"]}}}}`
+
+func TestMarkdownTagOrder(t *testing.T) {
+	md := GenerateMarkdownWithConfig(gjson.New(markdownOrderSpec), markdownOrderSpec, RenderConfig{})
+	got := headings(md, "Docs", "orders", "query", "Users", "admin", "misc", "x", "List orders", "List users", "Misc")
+	want := []string{
+		"# Docs",
+		"## orders", "### query", "#### List orders",
+		"## Users", "### admin", "#### List users",
+		"## misc", "### x", "#### Misc",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("headings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !strings.Contains(md, "##### 请求URL") {
+		t.Errorf("endpoint sections must be one level below the endpoint heading")
+	}
+}
+
+func TestMarkdownTagGroups(t *testing.T) {
+	spec := strings.Replace(markdownOrderSpec, `"tags":[`, `"x-tagGroups":[{"name":"People","tags":["users"]},{"name":"Shop","tags":["orders"]}],"tags":[`, 1)
+	md := GenerateMarkdownWithConfig(gjson.New(spec), spec, RenderConfig{})
+	got := headings(md, "Docs", "People", "Shop", "其他", "orders", "query", "Users", "admin", "misc", "x", "List orders", "List users", "Misc")
+	want := []string{
+		"# Docs",
+		"## People", "### Users", "#### admin", "##### List users",
+		"## Shop", "### orders", "#### query", "##### List orders",
+		"## 其他", "### misc", "#### x", "##### Misc",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("headings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !strings.Contains(md, "###### 请求URL") {
+		t.Errorf("endpoint sections must be demoted with the endpoint heading")
+	}
+	if strings.Count(md, "\n# ") != 0 || !strings.HasPrefix(md, "# Docs") {
+		t.Errorf("only the document title may be a level-one heading")
+	}
+}
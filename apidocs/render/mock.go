@@ -0,0 +1,328 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/source"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// MockResult 模拟接口的响应结果：状态码、媒体类型与响应体文本。
+type MockResult struct {
+	Status      int
+	ContentType string
+	Body        string
+	// Path 命中的规范路径模板（例如 /users/{id}）
+	Path string
+}
+
+// MockError 模拟请求失败的原因：未匹配到接口、路径参数校验失败或示例不存在；Status 为建议返回的状态码。
+type MockError struct {
+	Status  int
+	Message string
+}
+
+func (e *MockError) Error() string { return e.Message }
+
+// Mock 模拟服务的路由表：路径模板在创建时编译为正则，请求时只做匹配。
+type Mock struct {
+	routes []mockRoute
+}
+
+// mockRoute 单个路径模板及其编译结果；names 为模板中的参数名（按出现顺序）。
+type mockRoute struct {
+	tmpl  string
+	re    *regexp.Regexp
+	names []string
+}
+
+var pathParamRe = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// NewMock 按 paths 原始顺序编译规范中的全部路径模板（段内允许 {param} 与字面量混排，例如 /files/{name}.json）。
+func NewMock(j *gjson.Json, contentRaw string) *Mock {
+	keys := source.OrderedPathsFromContent(contentRaw)
+	if len(keys) == 0 {
+		for k := range j.GetJsonMap("paths") {
+			keys = append(keys, k)
+		}
+		sortStrings(keys)
+	}
+	m := &Mock{routes: make([]mockRoute, 0, len(keys))}
+	for _, k := range keys {
+		var pat strings.Builder
+		pat.WriteString("^")
+		var names []string
+		for _, seg := range strings.Split(strings.Trim(k, "/"), "/") {
+			pat.WriteString("/")
+			lits := pathParamRe.Split(seg, -1)
+			params := pathParamRe.FindAllStringSubmatch(seg, -1)
+			for n, lit := range lits {
+				pat.WriteString(regexp.QuoteMeta(lit))
+				if n < len(params) {
+					pat.WriteString("([^/]+)")
+					names = append(names, params[n][1])
+				}
+			}
+		}
+		pat.WriteString("$")
+		m.routes = append(m.routes, mockRoute{tmpl: k, re: regexp.MustCompile(pat.String()), names: names})
+	}
+	return m
+}
+
+// match 将请求路径与路径模板匹配，返回命中的模板与路径参数取值。
+// 多个模板命中时，选择模板参数最少（字面量段最多）的一个，保证 /users/me 优先于 /users/{id}。
+func (m *Mock) match(reqPath string) (string, map[string]string, bool) {
+	reqPath = "/" + strings.Trim(reqPath, "/")
+	var best *mockRoute
+	var sub []string
+	for i := range m.routes {
+		r := &m.routes[i]
+		if best != nil && len(r.names) >= len(best.names) {
+			continue
+		}
+		if v := r.re.FindStringSubmatch(reqPath); v != nil {
+			best, sub = r, v
+		}
+	}
+	if best == nil {
+		return "", nil, false
+	}
+	values := make(map[string]string, len(best.names))
+	for n, name := range best.names {
+		values[name] = sub[n+1]
+	}
+	return best.tmpl, values, true
+}
+
+// MockResponse 在规范中查找与 method+reqPath 匹配的接口，校验路径参数并返回示例响应（不应用定制规则）。
+// 多次调用时应使用 NewMock 复用编译后的路由表。
+func MockResponse(j *gjson.Json, contentRaw, method, reqPath, exampleName string) (MockResult, error) {
+	return NewMock(j, contentRaw).Response(j, RenderConfig{}, method, reqPath, exampleName)
+}
+
+// Response 返回 method+reqPath 的示例响应。
+// 说明：
+// - j 为创建 Mock 时使用的规范或其受众裁剪结果（裁剪不改变 paths 的键）；
+// - 路径匹配支持 {param} 模板，字面量路径优先于模板路径；
+// - 按 cfg 隐藏的接口（Customize.Hide、HideDeprecated）按未声明处理；
+// - 状态码优先 200，其次最小的 2xx，再次 default（按 200 返回）；
+// - exampleName 非空时从 content.<媒体类型>.examples 中选取同名示例，否则使用与文档一致的返回示例；
+// - 返回示例与文档共用 exampleValue：字段白名单/黑名单、重命名、示例覆盖，以及 writeOnly 与废弃字段的裁剪。
+func (m *Mock) Response(j *gjson.Json, cfg RenderConfig, method, reqPath, exampleName string) (MockResult, error) {
+	method = strings.ToLower(method)
+	tmpl, values, ok := m.match(reqPath)
+	if !ok {
+		return MockResult{}, &MockError{Status: 404, Message: "no mock for path " + reqPath}
+	}
+	pj := j.GetJsonMap("paths")[tmpl]
+	if pj == nil {
+		return MockResult{}, &MockError{Status: 404, Message: "no mock for path " + reqPath}
+	}
+	// 逐个方法解析定制规则：全部隐藏时按未声明的路径处理，请求的方法未声明或被隐藏时按未声明的方法处理
+	visible, found := false, false
+	var rules CustomizeReqAndRes
+	for _, pm := range presentMethods(pj) {
+		r := customizeFor(tmpl, pm, pj.GetJson(pm), cfg)
+		if cfg.hidesOperation(j, pj.GetJson(pm), r) {
+			continue
+		}
+		visible = true
+		if pm == method {
+			found, rules = true, r
+		}
+	}
+	if !visible {
+		return MockResult{}, &MockError{Status: 404, Message: "no mock for path " + reqPath}
+	}
+	if !found {
+		return MockResult{}, &MockError{Status: 405, Message: "method " + strings.ToUpper(method) + " not declared for " + tmpl}
+	}
+	mj := pj.GetJson(method)
+	if err := validatePathParams(j, pj, mj, values); err != nil {
+		return MockResult{}, err
+	}
+	code, resp := pickMockResponse(j, mj)
+	res := MockResult{Status: mockStatus(code), Path: tmpl}
+	if resp == nil {
+		return res, nil
+	}
+	ct, media := pickMediaType(resp.GetJsonMap("content"))
+	if media == nil {
+		return res, nil
+	}
+	res.ContentType = ct
+	var ex interface{}
+	if exampleName != "" {
+		named := media.GetJsonMap("examples")[exampleName]
+		if named == nil {
+			return MockResult{}, &MockError{Status: 400, Message: "example " + exampleName + " not declared for " + strings.ToUpper(method) + " " + tmpl}
+		}
+		if r := named.Get("$ref").String(); r != "" {
+			named = getRefJson(j, r)
+		}
+		if named != nil {
+			ex = named.Get("value").Val()
+		}
+	} else if schema := responseSchemaFor(j, resp); schema != nil {
+		ex = exampleValue(j, schema, rules.responseRules(), cfg.fieldView(viewResponse))
+	} else {
+		return res, nil
+	}
+	if s, ok := ex.(string); ok && !strings.Contains(ct, "json") {
+		res.Body = s
+		return res, nil
+	}
+	bs, _ := json.MarshalIndent(ex, "", "    ")
+	res.Body = string(bs)
+	return res, nil
+}
+
+// validatePathParams 按规范声明的 schema 校验路径参数（类型、枚举与 pattern）。
+func validatePathParams(j *gjson.Json, pathItem, op *gjson.Json, values map[string]string) error {
+	arr := append(pathItem.Get("parameters").Array(), op.Get("parameters").Array()...)
+	for _, v := range arr {
+		pj := gjson.New(v)
+		if ref := pj.Get("$ref").String(); ref != "" {
+			if pj = getRefJson(j, ref); pj == nil {
+				continue
+			}
+		}
+		if pj.Get("in").String() != "path" {
+			continue
+		}
+		name := pj.Get("name").String()
+		val, ok := values[name]
+		if !ok {
+			continue
+		}
+		s := pj.GetJson("schema")
+		if s == nil {
+			continue
+		}
+		if r := s.Get("$ref").String(); r != "" {
+			if s = getRefJson(j, r); s == nil {
+				continue
+			}
+		}
+		if msg := checkScalar(s, val); msg != "" {
+			return &MockError{Status: 400, Message: fmt.Sprintf("path parameter %s: %s", name, msg)}
+		}
+	}
+	return nil
+}
+
+// checkScalar 校验单个字符串取值是否满足 schema 的类型、枚举与 pattern，返回错误描述（空串表示通过）。
+func checkScalar(s *gjson.Json, val string) string {
+	switch s.Get("type").String() {
+	case "integer":
+		if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+			return "expected integer, got " + strconv.Quote(val)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(val, 64); err != nil {
+			return "expected number, got " + strconv.Quote(val)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(val); err != nil {
+			return "expected boolean, got " + strconv.Quote(val)
+		}
+	}
+	if enum := s.Get("enum").Strings(); len(enum) > 0 {
+		found := false
+		for _, e := range enum {
+			if e == val {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "expected one of [" + strings.Join(enum, ", ") + "], got " + strconv.Quote(val)
+		}
+	}
+	if p := s.Get("pattern").String(); p != "" {
+		if re, err := regexp.Compile(p); err == nil && !re.MatchString(val) {
+			return "does not match pattern " + p
+		}
+	}
+	return ""
+}
+
+// pickMockResponse 选择用于模拟的响应：优先 200，其次最小的 2xx，再次 default，最后取第一个声明的状态码。
+func pickMockResponse(j *gjson.Json, op *gjson.Json) (string, *gjson.Json) {
+	resps := op.GetJsonMap("responses")
+	codes := make([]string, 0, len(resps))
+	for c := range resps {
+		codes = append(codes, c)
+	}
+	sortStrings(codes)
+	pick := ""
+	if _, ok := resps["200"]; ok {
+		pick = "200"
+	}
+	if pick == "" {
+		for _, c := range codes {
+			if strings.HasPrefix(c, "2") {
+				pick = c
+				break
+			}
+		}
+	}
+	if pick == "" {
+		if _, ok := resps["default"]; ok {
+			pick = "default"
+		}
+	}
+	if pick == "" && len(codes) > 0 {
+		pick = codes[0]
+	}
+	if pick == "" {
+		return "200", nil
+	}
+	resp := resps[pick]
+	if r := resp.Get("$ref").String(); r != "" {
+		resp = getRefJson(j, r)
+	}
+	return pick, resp
+}
+
+// mockStatus 将响应键转为 HTTP 状态码；default 与 2XX 等范围写法按其区间的首个状态码处理。
+func mockStatus(code string) int {
+	if n, err := strconv.Atoi(code); err == nil {
+		return n
+	}
+	if len(code) == 3 && strings.HasSuffix(strings.ToUpper(code), "XX") {
+		if n, err := strconv.Atoi(code[:1]); err == nil {
+			return n * 100
+		}
+	}
+	return 200
+}
+
+// pickMediaType 从 content 映射中选择媒体类型：优先 JSON 家族，其次按名称排序的第一个。
+func pickMediaType(mp map[string]*gjson.Json) (string, *gjson.Json) {
+	if len(mp) == 0 {
+		return "", nil
+	}
+	for _, p := range []string{"application/json", "application/problem+json", "application/ld+json"} {
+		if v, ok := mp[p]; ok {
+			return p, v
+		}
+	}
+	keys := make([]string, 0, len(mp))
+	for k := range mp {
+		keys = append(keys, k)
+	}
+	sortStrings(keys)
+	for _, k := range keys {
+		if strings.Contains(k, "json") {
+			return k, mp[k]
+		}
+	}
+	return keys[0], mp[keys[0]]
+}
@@ -0,0 +1,126 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+const mockSpec = `{"paths":{
+	"/users/{id}":{
+		"parameters":[{"name":"id","in":"path","schema":{"type":"integer"}}],
+		"get":{"responses":{"404":{"description":"missing"},"200":{"content":{"application/json":{
+			"schema":{"type":"object","properties":{"id":{"type":"integer","default":7},"name":{"type":"string","default":"tom"},"secret":{"type":"string","default":"s"},"password":{"type":"string","writeOnly":true}}},
+			"examples":{"admin":{"value":{"id":1,"name":"root"}}}}}}}},
+		"delete":{"deprecated":true,"responses":{"204":{"description":"gone"}}}},
+	"/users/me":{"get":{"responses":{"201":{"content":{"text/plain":{"schema":{"type":"string","default":"me"}}}},"202":{"description":"later"}}}},
+	"/files/{name}.json":{"get":{"responses":{"default":{"content":{"application/json":{"schema":{"type":"object","properties":{"ok":{"type":"boolean"}}}}}}}}},
+	"/colors/{c}":{"get":{"parameters":[{"name":"c","in":"path","schema":{"type":"string","enum":["red","blue"]}}],"responses":{"2XX":{"description":"ok"}}}},
+	"/hidden":{"get":{"responses":{"200":{"description":"ok"}}}}}}`
+
+func TestMockMatch(t *testing.T) {
+	j := gjson.New(mockSpec)
+	m := NewMock(j, mockSpec)
+	cases := []struct {
+		path, tmpl string
+		values     map[string]string
+	}{
+		{"/users/42", "/users/{id}", map[string]string{"id": "42"}},
+		{"/users/me/", "/users/me", map[string]string{}},
+		{"/files/report.json", "/files/{name}.json", map[string]string{"name": "report"}},
+	}
+	for _, c := range cases {
+		tmpl, values, ok := m.match(c.path)
+		if !ok || tmpl != c.tmpl || !reflect.DeepEqual(values, c.values) {
+			t.Errorf("match(%s) = %s %v %v, want %s %v", c.path, tmpl, values, ok, c.tmpl, c.values)
+		}
+	}
+	for _, p := range []string{"/users", "/users/1/posts", "/files/report.xml"} {
+		if tmpl, _, ok := m.match(p); ok {
+			t.Errorf("match(%s) = %s, want no match", p, tmpl)
+		}
+	}
+}
+
+func TestMockResponseStatus(t *testing.T) {
+	j := gjson.New(mockSpec)
+	m := NewMock(j, mockSpec)
+	cases := []struct {
+		method, path string
+		status       int
+		contentType  string
+		body         string
+	}{
+		{"GET", "/users/me", 201, "text/plain", "me"},
+		{"GET", "/files/a.json", 200, "application/json", "{\n    \"ok\": false\n}"},
+		{"GET", "/colors/red", 200, "", ""},
+		{"DELETE", "/users/1", 204, "", ""},
+	}
+	for _, c := range cases {
+		res, err := m.Response(j, RenderConfig{}, c.method, c.path, "")
+		if err != nil {
+			t.Errorf("%s %s: %v", c.method, c.path, err)
+			continue
+		}
+		if res.Status != c.status || res.ContentType != c.contentType || res.Body != c.body {
+			t.Errorf("%s %s = %d %q %q, want %d %q %q", c.method, c.path, res.Status, res.ContentType, res.Body, c.status, c.contentType, c.body)
+		}
+	}
+}
+
+func TestMockResponseErrors(t *testing.T) {
+	j := gjson.New(mockSpec)
+	m := NewMock(j, mockSpec)
+	cfg := RenderConfig{HideDeprecated: true, Customize: map[string]CustomizeReqAndRes{"/hidden": {Hide: true}}}
+	cases := []struct {
+		method, path, example string
+		status                int
+	}{
+		{"GET", "/nope", "", 404},
+		{"POST", "/users/1", "", 405},
+		{"GET", "/users/abc", "", 400},
+		{"GET", "/colors/green", "", 400},
+		{"GET", "/users/1", "missing", 400},
+		{"DELETE", "/users/1", "", 405},
+		{"GET", "/hidden", "", 404},
+	}
+	for _, c := range cases {
+		_, err := m.Response(j, cfg, c.method, c.path, c.example)
+		var me *MockError
+		if !errors.As(err, &me) || me.Status != c.status {
+			t.Errorf("%s %s = %v, want status %d", c.method, c.path, err, c.status)
+		}
+	}
+}
+
+func TestMockResponseMatchesDocs(t *testing.T) {
+	j := gjson.New(mockSpec)
+	m := NewMock(j, mockSpec)
+	cfg := RenderConfig{Customize: map[string]CustomizeReqAndRes{"/users/{id}": {
+		Exclude: []string{"secret"},
+		Rename:  map[string]string{"name": "nickname"},
+		Fields:  map[string]FieldOverride{"id": {Example: 99}},
+	}}}
+	res, err := m.Response(j, cfg, "GET", "/users/5", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(res.Body), &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"id": float64(99), "nickname": "tom"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("body = %v, want %v", got, want)
+	}
+	ep := buildEndpoint(j, "/users/{id}", "get", j.GetJsonMap("paths")["/users/{id}"], j.GetJson("paths./users/{id}.get"), cfg)
+	if ep.ResponseExample != res.Body {
+		t.Errorf("mock body differs from docs example:\n%s\n%s", res.Body, ep.ResponseExample)
+	}
+	res, err = m.Response(j, cfg, "GET", "/users/5", "admin")
+	if err != nil || res.Body != "{\n    \"id\": 1,\n    \"name\": \"root\"\n}" {
+		t.Errorf("named example = %q, %v", res.Body, err)
+	}
+}
@@ -9,12 +9,12 @@ import (
 type suffixNode struct {
 	name     string
 	children map[string]*suffixNode
-	items    [][2]string
+	items    []NavItemVM
 	order    []string
 }
 
 // buildSuffixTree 根据所有次级分组字符串构建分组树；itemsMap 为每个原始分组对应的接口项列表。
-func buildSuffixTree(sufs []string, itemsMap map[string][][2]string) *suffixNode {
+func buildSuffixTree(sufs []string, itemsMap map[string][]NavItemVM) *suffixNode {
 	root := &suffixNode{name: "", children: make(map[string]*suffixNode), order: make([]string, 0, 8)}
 	for _, suf := range sufs {
 		parts := strings.Split(suf, "/")
@@ -60,7 +60,7 @@ func renderSuffixTreeMenu(b *strings.Builder, pre string, node *suffixNode, acc
 		b.WriteString("<details class=\"subgrp\" open><summary style=\"padding-left:" + fmt.Sprintf("%d", pad) + "px\"><a href=\"#" + childAcc + "\">" + htmlEscape(name) + "</a></summary>")
 		for _, it := range child.items {
 			ip := 28 + 14*depth
-			b.WriteString("<div class=\"item\" style=\"padding-left:" + fmt.Sprintf("%d", ip) + "px\"><a class=\"item-link\" href=\"#" + it[1] + "\">" + htmlEscape(it[0]) + "</a></div>")
+			b.WriteString("<div class=\"item\" style=\"padding-left:" + fmt.Sprintf("%d", ip) + "px\"><a class=\"item-link\" href=\"#" + it.Anchor + "\">" + htmlEscape(it.Summary) + "</a></div>")
 		}
 		renderSuffixTreeMenu(b, pre, child, childAcc, depth+1)
 		b.WriteString("</details>")
@@ -85,8 +85,9 @@ func renderSuffixTreeHeadings(b *strings.Builder, pre string, node *suffixNode,
 	}
 }
 
-// toNavVM 将树结构转换为视图模型，用于模板渲染侧边导航与正文标题。
-func toNavVM(pre string, node *suffixNode, acc string) []*NavGroupVM {
+// toNavVM 将树结构转换为视图模型，用于模板渲染侧边导航与正文标题；tagPath 为当前节点的分组路径（“主/次/...”），
+// 用于查找文档级 tags 中声明的展示名称与 anchors 中登记的锚点。
+func toNavVM(node *suffixNode, tagPath string, cat *tagCatalog, anchors pageAnchors) []*NavGroupVM {
 	if node == nil || len(node.children) == 0 {
 		return nil
 	}
@@ -94,40 +95,61 @@ func toNavVM(pre string, node *suffixNode, acc string) []*NavGroupVM {
 	res := make([]*NavGroupVM, 0, len(names))
 	for _, name := range names {
 		child := node.children[name]
-		childAcc := acc
-		if childAcc == "" {
-			childAcc = "group-" + slugify(pre)
-		}
-		childAcc = childAcc + "-" + slugify(name)
 		itms := make([]NavItemVM, 0, len(child.items))
 		for _, it := range child.items {
-			itms = append(itms, NavItemVM{Summary: it[0], Anchor: it[1]})
+			itms = append(itms, it)
 		}
-		vm := &NavGroupVM{Name: name, Id: childAcc, Items: itms}
-		vm.Children = toNavVM(pre, child, childAcc)
+		childPath := tagPath + "/" + name
+		vm := &NavGroupVM{Name: cat.label(childPath, name), Id: anchors.group(childPath), Items: itms}
+		vm.Children = toNavVM(child, childPath, cat, anchors)
 		res = append(res, vm)
 	}
 	return res
 }
 
-// buildTopNavGroups 构造顶层分组视图模型。
-func buildTopNavGroups(preOrder []string, sfxOrder map[string][]string, groups map[string]map[string][][2]string) []*NavGroupVM {
+// buildTopNavGroups 构造顶层分组视图模型；分组名称取文档级 tags 的 x-displayName（若有）。
+func buildTopNavGroups(preOrder []string, sfxOrder map[string][]string, groups map[string]map[string][]NavItemVM, cat *tagCatalog, anchors pageAnchors) []*NavGroupVM {
 	tops := make([]*NavGroupVM, 0, len(preOrder))
 	for _, pre := range preOrder {
 		tree := buildSuffixTree(sfxOrder[pre], groups[pre])
-		children := toNavVM(pre, tree, "")
-		tops = append(tops, &NavGroupVM{Name: pre, Id: "group-" + slugify(pre), Children: children})
+		children := toNavVM(tree, pre, cat, anchors)
+		tops = append(tops, &NavGroupVM{Name: cat.label(pre, pre), Id: anchors.group(pre), Children: children})
 	}
 	return tops
 }
 
+// buildNavTagGroups 按 x-tagGroups 将顶层分组归入各项（保持 ops 中的顺序）；未设置 x-tagGroups 时返回 nil。
+// tops 与 ops 中的一级分组一一对应（均按 ops 的首次出现顺序）。
+func buildNavTagGroups(ops []docOperation, tops []*NavGroupVM, cat *tagCatalog, anchors pageAnchors, loc Locale) []*NavTagGroupVM {
+	if len(cat.groups) == 0 {
+		return nil
+	}
+	var res []*NavTagGroupVM
+	seen := make(map[string]bool)
+	i := 0
+	for _, op := range ops {
+		if seen[op.Pre] {
+			continue
+		}
+		seen[op.Pre] = true
+		name := firstNonEmpty(op.TagGroup, loc.T("other_tags"))
+		if len(res) == 0 || res[len(res)-1].Name != name {
+			res = append(res, &NavTagGroupVM{Name: name, Id: anchors.tagGroup(name)})
+		}
+		last := res[len(res)-1]
+		last.Groups = append(last.Groups, tops[i])
+		i++
+	}
+	return res
+}
+
 // buildMainGroups 构造正文分组视图模型，用于生成 h1/h2 标题并对应锚点。
-func buildMainGroups(preOrder []string, sfxOrder map[string][]string, groups map[string]map[string][][2]string) []*MainGroupVM {
+func buildMainGroups(preOrder []string, sfxOrder map[string][]string, groups map[string]map[string][]NavItemVM, cat *tagCatalog, anchors pageAnchors) []*MainGroupVM {
 	res := make([]*MainGroupVM, 0, len(preOrder))
 	for _, pre := range preOrder {
 		tree := buildSuffixTree(sfxOrder[pre], groups[pre])
-		children := toNavVM(pre, tree, "group-"+slugify(pre))
-		res = append(res, &MainGroupVM{PreName: pre, PreId: "group-" + slugify(pre), Children: children})
+		children := toNavVM(tree, pre, cat, anchors)
+		res = append(res, &MainGroupVM{PreName: cat.label(pre, pre), PreId: anchors.group(pre), Children: children})
 	}
 	return res
 }
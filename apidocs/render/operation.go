@@ -0,0 +1,53 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// operationTags 接口生效的标签：Customize.Group 非空时替换 tags[0]（写法同 tags[0] 的“主/次”），其余标签保留。
+func operationTags(mj *gjson.Json, rules CustomizeReqAndRes) []string {
+	tags := jsonArrayStrings(mj.Get("tags").Array())
+	if g := strings.TrimSpace(rules.Group); g != "" {
+		if len(tags) == 0 {
+			return []string{g}
+		}
+		return append([]string{g}, tags[1:]...)
+	}
+	return tags
+}
+
+// operationSummary 接口标题：Customize.Summary 优先，其次为规范中的 summary，均缺失时为 “方法 路径”。
+func operationSummary(p, m string, mj *gjson.Json, rules CustomizeReqAndRes) string {
+	if s := strings.TrimSpace(rules.Summary); s != "" {
+		return s
+	}
+	if s := strings.TrimSpace(mj.Get("summary").String()); s != "" {
+		return s
+	}
+	return strings.ToUpper(m) + " " + p
+}
+
+// calloutMarkdown 以 Markdown 引用块输出说明或警告，如 “> **注意**：...”；多行内容逐行加引用前缀。
+func calloutMarkdown(label, text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	lines[0] = "**" + label + "**: " + lines[0]
+	return "> " + strings.Join(lines, "\n> ") + "\n\n"
+}
+
+// mdHeading 返回 level 级 Markdown 标题；超过六级时以加粗段落代替（Markdown 仅支持六级标题）。
+func mdHeading(level int, text string) string {
+	if level > 6 {
+		return "**" + text + "**\n\n"
+	}
+	return strings.Repeat("#", level) + " " + text + "\n\n"
+}
+
+// firstNonEmpty 返回 cur；cur 为空时返回 next（按优先级从高到低合并覆盖项时使用）。
+func firstNonEmpty(cur, next string) string {
+	if cur != "" {
+		return cur
+	}
+	return strings.TrimSpace(next)
+}
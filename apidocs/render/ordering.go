@@ -0,0 +1,91 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// 分组内接口的排序方式（RenderConfig.OperationOrder）。
+const (
+	// OrderSpec 按规范中 paths 的原始顺序（缺省）
+	OrderSpec = "spec"
+	// OrderPath 按路径字母序
+	OrderPath = "path"
+	// OrderSummary 按接口标题字母序（不区分大小写）
+	OrderSummary = "summary"
+)
+
+// 同一路径下方法的排序方式（RenderConfig.MethodOrder）。
+const (
+	// MethodOrderAlpha 按方法名字母序（缺省）
+	MethodOrderAlpha = "alpha"
+	// MethodOrderCanonical 按 GET、POST、PUT、PATCH、DELETE、HEAD、OPTIONS、TRACE
+	MethodOrderCanonical = "canonical"
+)
+
+// canonicalMethods 规范方法顺序。
+var canonicalMethods = []string{"get", "post", "put", "patch", "delete", "head", "options", "trace"}
+
+// operationSortKey 分组内排序使用的接口属性。
+type operationSortKey struct {
+	hasOrder  bool
+	order     float64
+	pathIndex int
+	path      string
+	summary   string
+	method    string
+	// methodRank 方法在规范顺序中的位置（仅 MethodOrderCanonical，否则为 0）
+	methodRank int
+}
+
+// sortKey 计算接口的分组内排序键；pathIndex 为路径在 paths 原始顺序中的位置。
+func (cfg RenderConfig) sortKey(p, m string, mj *gjson.Json, rules CustomizeReqAndRes, pathIndex int) operationSortKey {
+	k := operationSortKey{pathIndex: pathIndex, path: p, summary: strings.ToLower(operationSummary(p, m, mj, rules)), method: m}
+	if v := mj.Get("x-order"); !v.IsNil() {
+		k.hasOrder, k.order = true, v.Float64()
+	}
+	if strings.EqualFold(cfg.MethodOrder, MethodOrderCanonical) {
+		k.methodRank = len(canonicalMethods)
+		for i, x := range canonicalMethods {
+			if x == m {
+				k.methodRank = i
+			}
+		}
+	}
+	return k
+}
+
+// operationLess 分组内接口的先后：
+// - 声明了 x-order 的接口排在前面，按 x-order 升序；
+// - 其余按 OperationOrder：spec 为 paths 原始顺序，path 为路径字母序，summary 为标题字母序（相同时按路径）；
+// - 同一路径的方法按 MethodOrder 排序。
+func (cfg RenderConfig) operationLess(a, b operationSortKey) bool {
+	if a.hasOrder != b.hasOrder {
+		return a.hasOrder
+	}
+	if a.hasOrder && a.order != b.order {
+		return a.order < b.order
+	}
+	switch strings.ToLower(cfg.OperationOrder) {
+	case OrderSummary:
+		if a.summary != b.summary {
+			return a.summary < b.summary
+		}
+		if a.path != b.path {
+			return a.path < b.path
+		}
+	case OrderPath:
+		if a.path != b.path {
+			return a.path < b.path
+		}
+	default:
+		if a.pathIndex != b.pathIndex {
+			return a.pathIndex < b.pathIndex
+		}
+	}
+	if a.methodRank != b.methodRank {
+		return a.methodRank < b.methodRank
+	}
+	return a.method < b.method
+}
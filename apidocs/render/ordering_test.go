@@ -0,0 +1,57 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// orderSpec 同一分组下的接口：paths 原始顺序为 /b、/a、/c，/c 带 x-order，/b 声明了全部方法（含 trace）。
+const orderSpec = `{"paths":{
+	"/b":{"trace":{"summary":"Bt"},"delete":{"summary":"Bd"},"get":{"summary":"Bg"},"post":{"summary":"Bp"},"options":{"summary":"Bo"}},
+	"/a":{"get":{"summary":"zeta"}},
+	"/c":{"get":{"summary":"Alpha","x-order":2},"put":{"summary":"Alpha2","x-order":1}}}}`
+
+func orderedOps(t *testing.T, cfg RenderConfig) string {
+	t.Helper()
+	j := gjson.New(orderSpec)
+	ops := collectOperations(j, orderSpec, cfg, loadTagCatalog(j, cfg.groupDelimiter()))
+	res := make([]string, 0, len(ops))
+	for _, op := range ops {
+		res = append(res, strings.ToUpper(op.Method)+" "+op.Path)
+	}
+	return strings.Join(res, ",")
+}
+
+func TestOperationOrder(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  RenderConfig
+		want string
+	}{
+		{"spec order, alpha methods", RenderConfig{}, "PUT /c,GET /c,DELETE /b,GET /b,OPTIONS /b,POST /b,TRACE /b,GET /a"},
+		{"path order", RenderConfig{OperationOrder: OrderPath}, "PUT /c,GET /c,GET /a,DELETE /b,GET /b,OPTIONS /b,POST /b,TRACE /b"},
+		{"summary order", RenderConfig{OperationOrder: OrderSummary}, "PUT /c,GET /c,DELETE /b,GET /b,OPTIONS /b,POST /b,TRACE /b,GET /a"},
+		{"canonical methods", RenderConfig{MethodOrder: MethodOrderCanonical}, "PUT /c,GET /c,GET /b,POST /b,DELETE /b,OPTIONS /b,TRACE /b,GET /a"},
+		{"case-insensitive options", RenderConfig{OperationOrder: "PATH", MethodOrder: "Canonical"}, "PUT /c,GET /c,GET /a,GET /b,POST /b,DELETE /b,OPTIONS /b,TRACE /b"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := orderedOps(t, c.cfg); got != c.want {
+				t.Errorf("order = %s\nwant    %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestPresentMethodsIncludesTrace(t *testing.T) {
+	got := presentMethods(gjson.New(`{"TRACE":{},"get":{},"parameters":[],"summary":"x","x-foo":{}}`))
+	if strings.Join(got, ",") != "get,trace" {
+		t.Errorf("presentMethods = %v, want [get trace]", got)
+	}
+	page := GenerateHTMLWithConfig(gjson.New(orderSpec), orderSpec, RenderConfig{})
+	if !strings.Contains(page, "Bt") {
+		t.Errorf("trace operation missing from the docs page")
+	}
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+func TestOperationOverrides(t *testing.T) {
+	j := loadCustomizeTestSpec(t)
+	cfg := RenderConfig{
+		GroupDelimiter: ".",
+		// Customize.Group 优先于 GroupFunc
+		GroupFunc: func(p, m string, op *gjson.Json) [][]string { return [][]string{{"自定义"}} },
+		Customize: map[string]CustomizeReqAndRes{
+			"/v1/**":                     {Summary: "通配标题", Note: "通配说明", ContentType: "text/plain"},
+			"operationId:getUser":        {Summary: "  获取用户  ", Group: "用户.详情", Warning: "第一行\n第二行"},
+			"/v1/users/{userId}/orders":  {Hide: true},
+			"DELETE /v1/users/{userId}":  {Summary: " ", ContentType: "multipart/form-data"},
+			"tag:Admin":                  {Group: "管理"},
+			"GET /v2/admin/v1/users":     {Note: "仅限管理员"},
+			"operationId:does-not-exist": {Hide: true},
+		},
+	}
+	ops := collectOperations(j, customizeTestSpec, cfg, loadTagCatalog(j, cfg.groupDelimiter()))
+	var got []string
+	for _, op := range ops {
+		got = append(got, strings.ToUpper(op.Method)+" "+op.Path+" @"+groupPath(op.Pre, op.Suf))
+	}
+	// Hide 的接口不在页面中；Group 按 GroupDelimiter 拆分并优先于 GroupFunc
+	want := "DELETE /v1/users/{userId} @自定义/默认|GET /v1/users/{userId} @用户/详情|GET /v2/admin/v1/users @管理/默认"
+	if strings.Join(got, "|") != want {
+		t.Errorf("operations = %s, want %s", strings.Join(got, "|"), want)
+	}
+
+	paths := j.GetJsonMap("paths")
+	cases := []struct {
+		path, method                        string
+		summary, note, warning, contentType string
+	}{
+		// 优先级最高的规则设置了的项生效，未设置的项取其他命中规则
+		{"/v1/users/{userId}", "get", "获取用户", "通配说明", "第一行\n第二行", "text/plain"},
+		// 空白的 Summary 视为未设置，回退到优先级较低的规则
+		{"/v1/users/{userId}", "delete", "通配标题", "通配说明", "", "multipart/form-data"},
+		// 未设置 Summary 与 ContentType 时使用 summary（缺省为方法+路径）与请求体的媒体类型
+		{"/v2/admin/v1/users", "get", "GET /v2/admin/v1/users", "仅限管理员", "", "application/json"},
+	}
+	for _, c := range cases {
+		pj := paths[c.path]
+		ep := buildEndpoint(j, c.path, c.method, pj, pj.GetJson(c.method), cfg)
+		if ep.Summary != c.summary || ep.Note != c.note || ep.Warning != c.warning || ep.ContentType != c.contentType {
+			t.Errorf("%s %s: summary=%q note=%q warning=%q contentType=%q, want %q %q %q %q", c.method, c.path,
+				ep.Summary, ep.Note, ep.Warning, ep.ContentType, c.summary, c.note, c.warning, c.contentType)
+		}
+	}
+}
+
+func TestOperationOverridesOutput(t *testing.T) {
+	j := loadCustomizeTestSpec(t)
+	cfg := RenderConfig{Customize: map[string]CustomizeReqAndRes{
+		"operationId:getUser":       {Summary: "获取用户", Note: "需要 **登录**", Warning: "第一行\n第二行", ContentType: "multipart/form-data"},
+		"/v1/users/{userId}/orders": {Hide: true},
+	}}
+	page := GenerateHTMLWithConfig(j, customizeTestSpec, cfg)
+	for _, want := range []string{
+		`href="#get-v1-users-userId">获取用户</a>`,
+		`<div class="callout callout-warning"><strong>注意</strong><p>第一行<br>第二行</p></div>`,
+		`<div class="callout callout-note"><strong>说明</strong><p>需要 <strong>登录</strong></p>`,
+		"multipart/form-data",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("html missing %q", want)
+		}
+	}
+	md := GenerateMarkdownWithConfig(j, customizeTestSpec, cfg)
+	for _, want := range []string{
+		"获取用户",
+		"> **注意**: 第一行\n> 第二行\n\n",
+		"> **说明**: 需要 **登录**\n\n",
+		"Content-Type: multipart/form-data",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q", want)
+		}
+	}
+	for name, out := range map[string]string{"html": page, "markdown": md} {
+		if strings.Contains(out, "/v1/users/{userId}/orders") {
+			t.Errorf("%s shows the hidden operation", name)
+		}
+	}
+}
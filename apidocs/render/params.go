@@ -0,0 +1,136 @@
+package render
+
+import "strings"
+
+// ParamSpec 通过 Customize 注入的参数（结构化写法，Headers 的字符串写法仍然有效）。
+// - In: 参数位置（header/query/path/cookie，大小写不敏感），缺省为 header；其他取值的参数不注入（LoadCustomizeFile 报告为错误）
+// - Example: 示例值，以 JSON 文本展示在约束列；Enum: 可选值
+type ParamSpec struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+	Example     interface{}
+	Enum        []string
+}
+
+// paramIns 支持注入的参数位置。
+var paramIns = []string{"header", "query", "path", "cookie"}
+
+// IsParamIn 参数位置是否合法（header/query/path/cookie，大小写不敏感，忽略首尾空白）。
+func IsParamIn(in string) bool {
+	in = strings.ToLower(strings.TrimSpace(in))
+	for _, x := range paramIns {
+		if x == in {
+			return true
+		}
+	}
+	return false
+}
+
+// paramKey 参数的去重键：位置 + 名称；Header 名称大小写不敏感。
+func paramKey(in, name string) string {
+	in = strings.ToLower(strings.TrimSpace(in))
+	if in == "" {
+		in = "header"
+	}
+	if in == "header" {
+		name = strings.ToLower(name)
+	}
+	return in + ":" + name
+}
+
+// hasParam 列表中是否已有同位置同名的参数。
+func hasParam(list []ParamSpec, p ParamSpec) bool {
+	for _, x := range list {
+		if paramKey(x.In, x.Name) == paramKey(p.In, p.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// info 转为参数展示结构；位置不合法时 ok 为 false。
+func (p ParamSpec) info() (info ParamInfo, ok bool) {
+	in := strings.ToLower(strings.TrimSpace(p.In))
+	if in == "" {
+		in = "header"
+	}
+	if !IsParamIn(in) {
+		return ParamInfo{}, false
+	}
+	return ParamInfo{
+		Name:        p.Name,
+		In:          in,
+		Required:    p.Required || in == "path",
+		Type:        p.Type,
+		Desc:        p.Description,
+		Constraints: Constraints{Enum: p.Enum, Example: jsonText(p.Example)},
+	}, true
+}
+
+// injectedParams 接口的全部注入参数：先 Params（声明顺序，跳过位置不合法者），再 Headers 的字符串写法（按名称排序）；同位置同名者只保留首个。
+func injectedParams(rules CustomizeReqAndRes) []ParamInfo {
+	var res []ParamInfo
+	seen := map[string]struct{}{}
+	add := func(p ParamInfo) {
+		k := paramKey(p.In, p.Name)
+		if _, ok := seen[k]; ok || p.Name == "" {
+			return
+		}
+		seen[k] = struct{}{}
+		res = append(res, p)
+	}
+	for _, p := range rules.Params {
+		if info, ok := p.info(); ok {
+			add(info)
+		}
+	}
+	names := make([]string, 0, len(rules.Headers))
+	for name := range rules.Headers {
+		names = append(names, name)
+	}
+	sortStrings(names)
+	for _, name := range names {
+		t, req, desc := parseHeaderSpec(rules.Headers[name])
+		add(ParamInfo{Name: name, In: "header", Required: req, Type: t, Desc: desc})
+	}
+	return res
+}
+
+// endpointParams 接口的参数，按位置分类。
+type endpointParams struct {
+	Headers, Path, Query, Cookie []ParamInfo
+}
+
+// list 返回指定位置（大小写不敏感）的参数切片指针；调用方应先以 IsParamIn 校验位置。
+func (ps *endpointParams) list(in string) *[]ParamInfo {
+	switch strings.ToLower(in) {
+	case "path":
+		return &ps.Path
+	case "query":
+		return &ps.Query
+	case "cookie":
+		return &ps.Cookie
+	}
+	return &ps.Headers
+}
+
+// applyCustomizeParams 按接口的定制规则注入参数，跳过规范中已声明的同位置同名参数。
+func applyCustomizeParams(ps endpointParams, rules CustomizeReqAndRes) endpointParams {
+	exists := map[string]struct{}{}
+	for _, in := range paramIns {
+		for _, p := range *ps.list(in) {
+			exists[paramKey(in, p.Name)] = struct{}{}
+		}
+	}
+	for _, p := range injectedParams(rules) {
+		if _, ok := exists[paramKey(p.In, p.Name)]; ok {
+			continue
+		}
+		l := ps.list(p.In)
+		*l = append(*l, p)
+	}
+	return ps
+}
@@ -34,1649 +34,518 @@ func titleDescription(s *gjson.Json) string {
 	return d
 }
 
-// mergedProperties 合并 schema 的属性集：当 properties 为空时，
-// 会遍历 allOf/oneOf/anyOf 的每个元素并递归解析 $ref，最终返回统一的属性映射。
+// mergedProperties 合并 schema 的属性集：自身 properties 与 allOf 各元素（递归解析 $ref 与嵌套 allOf）的属性。
+// oneOf/anyOf 为互斥或可选组合，不并入属性集，由 variantGroupOf 单独展开。
 func mergedProperties(j *gjson.Json, s *gjson.Json) map[string]*gjson.Json {
-	props := s.GetJsonMap("properties")
-	if len(props) == 0 {
-		for _, arrName := range []string{"allOf", "oneOf", "anyOf"} {
-			for _, it := range s.Get(arrName).Array() {
-				elem := gjson.New(it)
-				if r := elem.Get("$ref").String(); r != "" {
-					sub := getRefJson(j, r)
-					if sub != nil {
-						for k, v := range sub.GetJsonMap("properties") {
-							props[k] = v
-						}
-					}
-				} else {
-					for k, v := range elem.GetJsonMap("properties") {
-						props[k] = v
-					}
-				}
-			}
+	props, _ := mergedObject(j, s, viewAll, map[string]bool{})
+	return props
+}
+
+// schemaView 字段视图（可按位组合）：请求视图隐藏 readOnly 属性，返回视图隐藏 writeOnly 属性，
+// viewNoDeprecated 隐藏已废弃属性；viewAll 不做隐藏。
+type schemaView int
+
+const (
+	viewAll     schemaView = 0
+	viewRequest schemaView = 1 << (iota - 1)
+	viewResponse
+	viewNoDeprecated
+)
+
+// hides 判断属性在该视图下是否隐藏；属性为 $ref 时同时检查引用组件上的标记。
+func (v schemaView) hides(j *gjson.Json, s *gjson.Json) bool {
+	if v == viewAll || s == nil {
+		return false
+	}
+	if v&viewNoDeprecated != 0 && deprecationOf(j, s).Deprecated {
+		return true
+	}
+	var key string
+	switch {
+	case v&viewRequest != 0:
+		key = "readOnly"
+	case v&viewResponse != 0:
+		key = "writeOnly"
+	default:
+		return false
+	}
+	if s.Get(key).Bool() {
+		return true
+	}
+	if r := s.Get("$ref").String(); r != "" {
+		if sub := getRefJson(j, r); sub != nil {
+			return sub.Get(key).Bool()
 		}
 	}
-	return props
+	return false
+}
+
+// mergedObject 递归合并 properties 与 required，并按视图剔除 readOnly/writeOnly 属性；seen 防止 allOf 循环引用。
+func mergedObject(j *gjson.Json, s *gjson.Json, view schemaView, seen map[string]bool) (map[string]*gjson.Json, map[string]bool) {
+	props := make(map[string]*gjson.Json)
+	req := make(map[string]bool)
+	if s == nil {
+		return props, req
+	}
+	if r := s.Get("$ref").String(); r != "" {
+		if seen[r] {
+			return props, req
+		}
+		seen[r] = true
+		defer delete(seen, r)
+		return mergedObject(j, getRefJson(j, r), view, seen)
+	}
+	for _, it := range s.Get("allOf").Array() {
+		p, rq := mergedObject(j, gjson.New(it), view, seen)
+		for k, v := range p {
+			props[k] = v
+		}
+		for k := range rq {
+			req[k] = true
+		}
+	}
+	for k, v := range s.GetJsonMap("properties") {
+		if view.hides(j, v) {
+			continue
+		}
+		props[k] = v
+	}
+	for k := range setFromArray(s.Get("required").Array()) {
+		req[k] = true
+	}
+	return props, req
 }
 
 // FieldInfo 用于参数/返回说明的扁平表结构。
+// 约束（枚举、格式、范围等）取自字段 schema；基础类型数组取元素的约束并合并数组自身的元素数约束。
 type FieldInfo struct {
 	Path     string
 	Required bool
 	Type     string
 	Desc     string
+	Constraints
+	Deprecation
 }
 
-// paramInfo 用于 Header/Path/Query 参数列表的展示结构。
-type paramInfo struct {
+// ParamInfo 用于 Header/Path/Query/Cookie 参数列表的展示结构。
+// - In: 参数位置（header/path/query/cookie）
+type ParamInfo struct {
 	Name     string
-	Required string
+	In       string
+	Required bool
 	Type     string
 	Desc     string
+	Constraints
+	Deprecation
 }
 
-// renderParamInfoTableHTML 将参数列表渲染为 HTML 表格。
-func renderParamInfoTableHTML(list []paramInfo) string {
-	var b strings.Builder
-	b.WriteString("<table><thead><tr><th>参数名</th><th>必选</th><th>类型</th><th>说明</th></tr></thead><tbody>")
-	for _, it := range list {
-		b.WriteString("<tr><td>" + htmlEscape(it.Name) + "</td><td>" + it.Required + "</td><td>" + htmlEscape(it.Type) + "</td><td>" + htmlEscape(it.Desc) + "</td></tr>")
+// requiredLabel 将必选标记转为表格展示文本。
+func requiredLabel(required bool, loc Locale) string {
+	if required {
+		return loc.T("yes")
 	}
-	b.WriteString("</tbody></table>")
-	return b.String()
+	return loc.T("no")
 }
 
-// renderParamInfoTableMarkdown 将参数列表渲染为 Markdown 表格。
-func renderParamInfoTableMarkdown(list []paramInfo) string {
-	var b strings.Builder
-	b.WriteString("| 参数名 | 必选 | 类型 | 说明 |\n|---|---|---|---|\n")
+// paramsHaveConstraints 是否有任一参数设置了约束（决定是否输出约束列）。
+func paramsHaveConstraints(list []ParamInfo) bool {
 	for _, it := range list {
-		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", it.Name, it.Required, it.Type, it.Desc))
-	}
-	return b.String()
-}
-
-// filterFieldInfos 根据白名单过滤字段行；支持顶层保留与 data 叶子名简写。
-func filterFieldInfos(fields []FieldInfo, allowed []string) []FieldInfo {
-	if len(allowed) == 0 {
-		return fields
-	}
-	norm := make([]string, 0, len(allowed))
-	for _, a := range allowed {
-		if a == "" {
-			continue
-		}
-		a = strings.ReplaceAll(a, ".items", "")
-		for strings.Contains(a, "[][]") {
-			a = strings.ReplaceAll(a, "[][]", "[]")
+		if it.HasConstraints() {
+			return true
 		}
-		norm = append(norm, a)
 	}
-	out := make([]FieldInfo, 0, len(fields))
-	for _, f := range fields {
-		if f.Path == "code" || f.Path == "message" || f.Path == "data" {
-			out = append(out, f)
-			continue
-		}
-		keep := false
-		for _, a := range norm {
-			if a == "" {
-				continue
-			}
-			if f.Path == a || strings.HasPrefix(f.Path, a+".") || strings.HasPrefix(f.Path, a+"[]") || strings.HasPrefix(f.Path, a+"[].") {
-				keep = true
-				break
-			}
-			if strings.HasPrefix(f.Path, "data") && !strings.ContainsAny(a, ".[]") {
-				clean := strings.ReplaceAll(f.Path, "[]", "")
-				parts := strings.Split(clean, ".")
-				last := parts[len(parts)-1]
-				if last == a {
-					t := f.Type
-					if t != "object" && !strings.HasPrefix(t, "object(") {
-						keep = true
-						break
-					}
-				}
-			}
-		}
-		if keep {
-			out = append(out, f)
-		}
-	}
-	return out
+	return false
 }
 
-// renderParamTableHTMLWithAllowed 渲染请求参数（通过 $ref）并按白名单过滤。
-func renderParamTableHTMLWithAllowed(j *gjson.Json, ref string, allowed []string) string {
-	fields := flattenSchemaFields(j, ref, "")
-	fields = filterFieldInfos(fields, allowed)
-	var b strings.Builder
-	b.WriteString("<table><thead><tr><th>参数名</th><th>必选</th><th>类型</th><th>说明</th></tr></thead><tbody>")
+// fieldsHaveConstraints 是否有任一字段设置了约束（决定是否输出约束列）。
+func fieldsHaveConstraints(fields []FieldInfo) bool {
 	for _, f := range fields {
-		req := "否"
-		if f.Required {
-			req = "是"
+		if f.HasConstraints() {
+			return true
 		}
-		b.WriteString("<tr><td>" + htmlEscape(f.Path) + "</td><td>" + req + "</td><td>" + htmlEscape(f.Type) + "</td><td>" + htmlEscape(f.Desc) + "</td></tr>")
 	}
-	b.WriteString("</tbody></table>")
-	return b.String()
+	return false
 }
 
-// renderParamTableMarkdownWithAllowed 渲染请求参数为 Markdown（通过 $ref）并按白名单过滤。
-func renderParamTableMarkdownWithAllowed(j *gjson.Json, ref string, allowed []string) string {
-	fields := flattenSchemaFields(j, ref, "")
-	fields = filterFieldInfos(fields, allowed)
+// tableHeadHTML 渲染 HTML 表头。
+func tableHeadHTML(cols ...string) string {
 	var b strings.Builder
-	b.WriteString("| 参数名 | 必选 | 类型 | 说明 |\n|---|---|---|---|\n")
-	for _, f := range fields {
-		req := "否"
-		if f.Required {
-			req = "是"
-		}
-		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", f.Path, req, f.Type, f.Desc))
+	b.WriteString("<table><thead><tr>")
+	for _, c := range cols {
+		b.WriteString("<th>" + c + "</th>")
 	}
+	b.WriteString("</tr></thead><tbody>")
 	return b.String()
 }
 
-// renderParamTableHTMLFromJsonWithAllowed 渲染请求参数（内联 schema）并按白名单过滤。
-func renderParamTableHTMLFromJsonWithAllowed(j *gjson.Json, sj *gjson.Json, allowed []string) string {
-	fields := flattenSchemaFieldsFromJson(j, sj, "")
-	fields = filterFieldInfos(fields, allowed)
-	var b strings.Builder
-	b.WriteString("<table><thead><tr><th>参数名</th><th>必选</th><th>类型</th><th>说明</th></tr></thead><tbody>")
-	for _, f := range fields {
-		req := "否"
-		if f.Required {
-			req = "是"
-		}
-		b.WriteString("<tr><td>" + htmlEscape(f.Path) + "</td><td>" + req + "</td><td>" + htmlEscape(f.Type) + "</td><td>" + htmlEscape(f.Desc) + "</td></tr>")
-	}
-	b.WriteString("</tbody></table>")
-	return b.String()
+// tableHeadMarkdown 渲染 Markdown 表头与分隔行。
+func tableHeadMarkdown(cols ...string) string {
+	return "| " + strings.Join(cols, " | ") + " |\n|" + strings.Repeat("---|", len(cols)) + "\n"
 }
 
-// renderParamTableHTML 渲染请求参数（通过 $ref）。
-func renderParamTableHTML(j *gjson.Json, ref string) string {
-	fields := flattenSchemaFields(j, ref, "")
+// renderParamInfoTableHTML 将参数列表渲染为 HTML 表格；任一参数有约束时追加约束列。
+func renderParamInfoTableHTML(list []ParamInfo, loc Locale) string {
+	withC := paramsHaveConstraints(list)
+	cols := []string{loc.T("col_name"), loc.T("col_required"), loc.T("col_type"), loc.T("col_desc")}
+	if withC {
+		cols = append(cols, loc.T("col_constraints"))
+	}
 	var b strings.Builder
-	b.WriteString("<table><thead><tr><th>参数名</th><th>必选</th><th>类型</th><th>说明</th></tr></thead><tbody>")
-	for _, f := range fields {
-		req := "否"
-		if f.Required {
-			req = "是"
+	b.WriteString(tableHeadHTML(cols...))
+	for _, it := range list {
+		b.WriteString("<tr><td>" + nameCellHTML(it.Name, it.Deprecation, loc) + "</td><td>" + requiredLabel(it.Required, loc) + "</td><td>" + htmlEscape(it.Type) + "</td><td>" + htmlEscape(it.Desc) + "</td>")
+		if withC {
+			b.WriteString("<td>" + constraintsHTML(it.Constraints, loc) + "</td>")
 		}
-		b.WriteString("<tr><td>" + htmlEscape(f.Path) + "</td><td>" + req + "</td><td>" + htmlEscape(f.Type) + "</td><td>" + htmlEscape(f.Desc) + "</td></tr>")
+		b.WriteString("</tr>")
 	}
 	b.WriteString("</tbody></table>")
 	return b.String()
 }
 
-// renderParamTableMarkdown 渲染请求参数为 Markdown（通过 $ref）。
-func renderParamTableMarkdown(j *gjson.Json, ref string) string {
-	fields := flattenSchemaFields(j, ref, "")
+// renderParamInfoTableMarkdown 将参数列表渲染为 Markdown 表格。
+func renderParamInfoTableMarkdown(list []ParamInfo, loc Locale) string {
+	withC := paramsHaveConstraints(list)
+	cols := []string{loc.T("col_name"), loc.T("col_required"), loc.T("col_type"), loc.T("col_desc")}
+	if withC {
+		cols = append(cols, loc.T("col_constraints"))
+	}
 	var b strings.Builder
-	b.WriteString("| 参数名 | 必选 | 类型 | 说明 |\n|---|---|---|---|\n")
-	for _, f := range fields {
-		req := "否"
-		if f.Required {
-			req = "是"
+	b.WriteString(tableHeadMarkdown(cols...))
+	for _, it := range list {
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |", nameCellMarkdown(it.Name, it.Deprecation, loc), requiredLabel(it.Required, loc), it.Type, it.Desc))
+		if withC {
+			b.WriteString(" " + constraintsMarkdown(it.Constraints, loc) + " |")
 		}
-		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", f.Path, req, f.Type, f.Desc))
+		b.WriteString("\n")
 	}
 	return b.String()
 }
 
-// renderParamTableHTMLFromJson 渲染请求参数（内联 schema）。
-func renderParamTableHTMLFromJson(j *gjson.Json, sj *gjson.Json) string {
-	fields := flattenSchemaFieldsFromJson(j, sj, "")
+// renderRequestFieldTableHTML 将请求参数行渲染为 HTML 表格（参数名/必选/类型/说明[/约束]）。
+func renderRequestFieldTableHTML(fields []FieldInfo, loc Locale) string {
+	withC := fieldsHaveConstraints(fields)
+	cols := []string{loc.T("col_name"), loc.T("col_required"), loc.T("col_type"), loc.T("col_desc")}
+	if withC {
+		cols = append(cols, loc.T("col_constraints"))
+	}
 	var b strings.Builder
-	b.WriteString("<table><thead><tr><th>参数名</th><th>必选</th><th>类型</th><th>说明</th></tr></thead><tbody>")
+	b.WriteString(tableHeadHTML(cols...))
 	for _, f := range fields {
-		req := "否"
-		if f.Required {
-			req = "是"
+		b.WriteString("<tr><td>" + nameCellHTML(f.Path, f.Deprecation, loc) + "</td><td>" + requiredLabel(f.Required, loc) + "</td><td>" + htmlEscape(f.Type) + "</td><td>" + htmlEscape(f.Desc) + "</td>")
+		if withC {
+			b.WriteString("<td>" + constraintsHTML(f.Constraints, loc) + "</td>")
 		}
-		b.WriteString("<tr><td>" + htmlEscape(f.Path) + "</td><td>" + req + "</td><td>" + htmlEscape(f.Type) + "</td><td>" + htmlEscape(f.Desc) + "</td></tr>")
+		b.WriteString("</tr>")
 	}
 	b.WriteString("</tbody></table>")
 	return b.String()
 }
 
-// renderParamTableMarkdownFromJson 渲染请求参数为 Markdown（内联 schema）。
-func renderParamTableMarkdownFromJson(j *gjson.Json, sj *gjson.Json) string {
-	fields := flattenSchemaFieldsFromJson(j, sj, "")
+// renderRequestFieldTableMarkdown 将请求参数行渲染为 Markdown 表格。
+func renderRequestFieldTableMarkdown(fields []FieldInfo, loc Locale) string {
+	withC := fieldsHaveConstraints(fields)
+	cols := []string{loc.T("col_name"), loc.T("col_required"), loc.T("col_type"), loc.T("col_desc")}
+	if withC {
+		cols = append(cols, loc.T("col_constraints"))
+	}
 	var b strings.Builder
-	b.WriteString("| 参数名 | 必选 | 类型 | 说明 |\n|---|---|---|---|\n")
+	b.WriteString(tableHeadMarkdown(cols...))
 	for _, f := range fields {
-		req := "否"
-		if f.Required {
-			req = "是"
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |", nameCellMarkdown(f.Path, f.Deprecation, loc), requiredLabel(f.Required, loc), f.Type, f.Desc))
+		if withC {
+			b.WriteString(" " + constraintsMarkdown(f.Constraints, loc) + " |")
 		}
-		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", f.Path, req, f.Type, f.Desc))
+		b.WriteString("\n")
 	}
 	return b.String()
 }
 
-// renderParamTableMarkdownFromJsonWithAllowed 渲染“请求参数”为 Markdown 表格（内联 schema），
-// 并按 allowed 白名单过滤，仅保留顶层 code/message/data 与命中的 data 下叶子或完整路径字段。
-func renderParamTableMarkdownFromJsonWithAllowed(j *gjson.Json, sj *gjson.Json, allowed []string) string {
-	fields := flattenSchemaFieldsFromJson(j, sj, "")
-	fields = filterFieldInfos(fields, allowed)
+// renderResponseFieldTableHTML 将返回参数行渲染为 HTML 表格（字段/类型/说明[/约束]），类型去除组件名装饰。
+func renderResponseFieldTableHTML(fields []FieldInfo, loc Locale) string {
+	withC := fieldsHaveConstraints(fields)
+	cols := []string{loc.T("col_field"), loc.T("col_type"), loc.T("col_desc")}
+	if withC {
+		cols = append(cols, loc.T("col_constraints"))
+	}
 	var b strings.Builder
-	b.WriteString("| 参数名 | 必选 | 类型 | 说明 |\n|---|---|---|---|\n")
+	b.WriteString(tableHeadHTML(cols...))
+	if len(fields) == 0 {
+		b.WriteString(fmt.Sprintf("<tr><td colspan=%d>%s</td></tr>", len(cols), loc.T("no_fields")))
+	}
 	for _, f := range fields {
-		req := "否"
-		if f.Required {
-			req = "是"
+		b.WriteString("<tr><td>" + nameCellHTML(f.Path, f.Deprecation, loc) + "</td><td>" + htmlEscape(stripComponentTypeDecorations(sanitizeType(f.Type))) + "</td><td>" + htmlEscape(f.Desc) + "</td>")
+		if withC {
+			b.WriteString("<td>" + constraintsHTML(f.Constraints, loc) + "</td>")
 		}
-		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", f.Path, req, f.Type, f.Desc))
+		b.WriteString("</tr>")
 	}
+	b.WriteString("</tbody></table>")
 	return b.String()
 }
 
-// renderResponseParamFlatTableHTMLFromJson 渲染返回参数说明为 HTML（自动展开 data）。
-func renderResponseParamFlatTableHTMLFromJson(j *gjson.Json, sj *gjson.Json) string {
-	target := sj
-	prefix := ""
-	for _, key := range []string{"data", "payload", "result", "content"} {
-		if !sj.Get("properties." + key).IsNil() {
-			d := sj.GetJson("properties." + key)
-			if r := d.Get("$ref").String(); r != "" {
-				target = getSchema(j, componentNameFromRef(r))
-			} else {
-				target = d
-			}
-			if target != sj {
-				prefix = key
-			}
-			break
-		}
-	}
-	var fields []FieldInfo
-	if prefix != "" {
-		t := target.Get("type").String()
-		if t == "array" {
-			it := target.GetJson("items")
-			if it != nil {
-				if r := it.Get("$ref").String(); r != "" {
-					t = "array(object)"
-				} else if it.Get("type").String() != "" {
-					t = "array(" + it.Get("type").String() + ")"
-				} else {
-					t = "array"
-				}
-			} else {
-				t = "array"
-			}
-		} else if t == "" {
-			t = "object"
-		}
-		desc := sj.Get("properties." + prefix + ".description").String()
-		fields = append(fields, FieldInfo{Path: prefix, Type: t, Desc: desc})
-	}
-	top := mergedProperties(j, sj)
-	if len(top) > 0 {
-		keysTop := make([]string, 0, len(top))
-		for k := range top {
-			if prefix == "" || k != prefix {
-				keysTop = append(keysTop, k)
-			}
-		}
-		sortStrings(keysTop)
-		for _, k := range keysTop {
-			p := top[k]
-			t := p.Get("type").String()
-			if r := p.Get("$ref").String(); r != "" {
-				t = "object"
-			} else if t == "array" {
-				it0 := p.GetJson("items")
-				if it0 != nil {
-					if r0 := it0.Get("$ref").String(); r0 != "" {
-						t = "array(object)"
-					} else if it0.Get("type").String() != "" {
-						t = "array(" + it0.Get("type").String() + ")"
-					} else {
-						t = "array"
-					}
-				} else {
-					t = "array"
-				}
-			} else if t == "" {
-				t = "object"
-			}
-			fields = append(fields, FieldInfo{Path: k, Type: t, Desc: p.Get("description").String()})
-		}
-	}
-	if target.Get("type").String() == "array" {
-		it := target.GetJson("items")
-		if it != nil {
-			if r := it.Get("$ref").String(); r != "" {
-				sub := getRefJson(j, r)
-				if sub != nil {
-					for k, p := range mergedProperties(j, sub) {
-						t := p.Get("type").String()
-						if rr := p.Get("$ref").String(); rr != "" {
-							t = "object"
-						}
-						if t == "array" {
-							it2 := p.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									t = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									t = "array(" + it2.Get("type").String() + ")"
-								} else {
-									t = "array"
-								}
-							} else {
-								t = "array"
-							}
-						}
-						fields = append(fields, FieldInfo{Path: prefix + "[]." + k, Type: t, Desc: p.Get("description").String()})
-					}
-				}
-			} else if it.Get("type").String() == "object" {
-				props := mergedProperties(j, it)
-				keys := make([]string, 0, len(props))
-				for k := range props {
-					keys = append(keys, k)
-				}
-				sortStrings(keys)
-				for _, k := range keys {
-					p := props[k]
-					t := p.Get("type").String()
-					if rr := p.Get("$ref").String(); rr != "" {
-						t = "object"
-					}
-					if t == "array" {
-						it2 := p.GetJson("items")
-						if it2 != nil {
-							if r2 := it2.Get("$ref").String(); r2 != "" {
-								t = "array(object)"
-							} else if it2.Get("type").String() != "" {
-								t = "array(" + it2.Get("type").String() + ")"
-							} else {
-								t = "array"
-							}
-						} else {
-							t = "array"
-						}
-					} else if t == "" {
-						t = "object"
-					}
-					fields = append(fields, FieldInfo{Path: prefix + "[]." + k, Type: t, Desc: p.Get("description").String()})
-				}
-			} else {
-				tt := it.Get("type").String()
-				if tt != "" {
-					fields = append(fields, FieldInfo{Path: prefix + "[]", Type: "array(" + tt + ")", Desc: it.Get("description").String()})
-				}
-			}
-		}
-	} else {
-		props := mergedProperties(j, target)
-		keys := make([]string, 0, len(props))
-		for k := range props {
-			keys = append(keys, k)
-		}
-		sortStrings(keys)
-		for _, k := range keys {
-			p := props[k]
-			cur := k
-			if prefix != "" {
-				cur = prefix + "." + k
-			}
-			t := p.Get("type").String()
-			if r := p.Get("$ref").String(); r != "" {
-				fields = append(fields, FieldInfo{Path: cur, Type: "object", Desc: p.Get("description").String()})
-				sub := getRefJson(j, r)
-				if sub != nil {
-					for sk, sp := range mergedProperties(j, sub) {
-						st := sp.Get("type").String()
-						if rr := sp.Get("$ref").String(); rr != "" {
-							st = "object"
-						}
-						if st == "array" {
-							it2 := sp.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									st = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									st = "array(" + it2.Get("type").String() + ")"
-								} else {
-									st = "array"
-								}
-							} else {
-								st = "array"
-							}
-						}
-						fields = append(fields, FieldInfo{Path: cur + "." + sk, Type: st, Desc: sp.Get("description").String()})
-					}
-				}
-				continue
-			}
-			if t == "array" {
-				it := p.GetJson("items")
-				if it != nil {
-					if r2 := it.Get("$ref").String(); r2 != "" {
-						fields = append(fields, FieldInfo{Path: cur + "[]", Type: "array(object)", Desc: p.Get("description").String()})
-						sub := getRefJson(j, r2)
-						if sub != nil {
-							for sk, sp := range sub.GetJsonMap("properties") {
-								st := sp.Get("type").String()
-								if rr := sp.Get("$ref").String(); rr != "" {
-									st = "object"
-								}
-								if st == "array" {
-									it2 := sp.GetJson("items")
-									if it2 != nil {
-										if r3 := it2.Get("$ref").String(); r3 != "" {
-											st = "array(object)"
-										} else if it2.Get("type").String() != "" {
-											st = "array(" + it2.Get("type").String() + ")"
-										} else {
-											st = "array"
-										}
-									} else {
-										st = "array"
-									}
-								}
-								fields = append(fields, FieldInfo{Path: cur + "[]." + sk, Type: st, Desc: sp.Get("description").String()})
-							}
-						}
-						continue
-					}
-					if it.Get("type").String() == "object" {
-						props2 := mergedProperties(j, it)
-						k2 := make([]string, 0, len(props2))
-						for kk := range props2 {
-							k2 = append(k2, kk)
-						}
-						sortStrings(k2)
-						for _, kk := range k2 {
-							sp := props2[kk]
-							st := sp.Get("type").String()
-							if rr := sp.Get("$ref").String(); rr != "" {
-								st = "object"
-							}
-							if st == "array" {
-								it3 := sp.GetJson("items")
-								if it3 != nil {
-									if r3 := it3.Get("$ref").String(); r3 != "" {
-										st = "array(object)"
-									} else if it3.Get("type").String() != "" {
-										st = "array(" + it3.Get("type").String() + ")"
-									} else {
-										st = "array"
-									}
-								} else {
-									st = "array"
-								}
-							}
-							fields = append(fields, FieldInfo{Path: cur + "[]." + kk, Type: st, Desc: sp.Get("description").String()})
-						}
-						continue
-					}
-					t = "array(" + it.Get("type").String() + ")"
-				} else {
-					t = "array"
-				}
-			} else if t == "" {
-				t = "object"
-			}
-			fields = append(fields, FieldInfo{Path: cur, Type: t, Desc: p.Get("description").String()})
-		}
+// renderResponseFieldTableMarkdown 将返回参数行渲染为 Markdown 表格。
+func renderResponseFieldTableMarkdown(fields []FieldInfo, loc Locale) string {
+	withC := fieldsHaveConstraints(fields)
+	cols := []string{loc.T("col_field"), loc.T("col_type"), loc.T("col_desc")}
+	if withC {
+		cols = append(cols, loc.T("col_constraints"))
 	}
 	var b strings.Builder
-	b.WriteString("<table><thead><tr><th>字段</th><th>类型</th><th>说明</th></tr></thead><tbody>")
+	b.WriteString(tableHeadMarkdown(cols...))
 	if len(fields) == 0 {
-		if target.Get("type").String() == "array" {
-			it := target.GetJson("items")
-			if it != nil {
-				props := it.GetJsonMap("properties")
-				if len(props) == 0 {
-					tt := it.Get("type").String()
-					desc := it.Get("description").String()
-					if tt != "" {
-						b.WriteString("<tr><td>" + htmlEscape(prefix+"[]") + "</td><td>" + htmlEscape("array("+tt+")") + "</td><td>" + htmlEscape(desc) + "</td></tr>")
-					} else {
-						b.WriteString("<tr><td colspan=3>无字段</td></tr>")
-					}
-				} else {
-					keys := make([]string, 0, len(props))
-					for k := range props {
-						keys = append(keys, k)
-					}
-					sortStrings(keys)
-					for _, k := range keys {
-						p := props[k]
-						t := p.Get("type").String()
-						if r := p.Get("$ref").String(); r != "" {
-							t = "object"
-						} else if t == "array" {
-							it2 := p.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									t = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									t = "array(" + it2.Get("type").String() + ")"
-								} else {
-									t = "array"
-								}
-							} else {
-								t = "array"
-							}
-						} else if t == "" {
-							t = "object"
-						}
-						desc := p.Get("description").String()
-						path := prefix + "[]." + k
-						b.WriteString("<tr><td>" + htmlEscape(path) + "</td><td>" + htmlEscape(sanitizeType(t)) + "</td><td>" + htmlEscape(desc) + "</td></tr>")
-					}
-				}
-			} else {
-				b.WriteString("<tr><td colspan=3>无字段</td></tr>")
-			}
-		} else {
-			props := target.GetJsonMap("properties")
-			if len(props) == 0 {
-				b.WriteString("<tr><td colspan=3>无字段</td></tr>")
-			} else {
-				keys := make([]string, 0, len(props))
-				for k := range props {
-					keys = append(keys, k)
-				}
-				sortStrings(keys)
-				for _, k := range keys {
-					p := props[k]
-					t := p.Get("type").String()
-					if r := p.Get("$ref").String(); r != "" {
-						t = "object"
-					} else if t == "array" {
-						it2 := p.GetJson("items")
-						if it2 != nil {
-							if r2 := it2.Get("$ref").String(); r2 != "" {
-								t = "array(object)"
-							} else if it2.Get("type").String() != "" {
-								t = "array(" + it2.Get("type").String() + ")"
-							} else {
-								t = "array"
-							}
-						} else {
-							t = "array"
-						}
-					} else if t == "" {
-						t = "object"
-					}
-					desc := p.Get("description").String()
-					path := k
-					if prefix != "" {
-						path = prefix + "." + k
-					}
-					b.WriteString("<tr><td>" + htmlEscape(path) + "</td><td>" + htmlEscape(sanitizeType(t)) + "</td><td>" + htmlEscape(desc) + "</td></tr>")
-				}
-			}
-		}
+		b.WriteString("| " + loc.T("no_fields") + " |  |  |\n")
 	}
 	for _, f := range fields {
-		b.WriteString("<tr><td>" + htmlEscape(f.Path) + "</td><td>" + htmlEscape(sanitizeType(f.Type)) + "</td><td>" + htmlEscape(f.Desc) + "</td></tr>")
+		b.WriteString(fmt.Sprintf("| %s | %s | %s |", nameCellMarkdown(f.Path, f.Deprecation, loc), stripComponentTypeDecorations(sanitizeType(f.Type)), f.Desc))
+		if withC {
+			b.WriteString(" " + constraintsMarkdown(f.Constraints, loc) + " |")
+		}
+		b.WriteString("\n")
 	}
-	b.WriteString("</tbody></table>")
 	return b.String()
 }
 
-func renderResponseParamFlatTableHTMLFromJsonWithAllowed(j *gjson.Json, sj *gjson.Json, allowed []string) string {
-	target := sj
-	if !sj.Get("properties.data").IsNil() {
-		d := sj.GetJson("properties.data")
-		if r := d.Get("$ref").String(); r != "" {
-			target = getSchema(j, componentNameFromRef(r))
-		} else {
-			target = d
-		}
-	}
-	prefix := ""
-	if target != sj {
-		prefix = "data"
+// filterFieldInfos 根据白名单过滤字段行；支持顶层保留与 data 叶子名简写。
+func filterFieldInfos(fields []FieldInfo, allowed []string) []FieldInfo {
+	if len(allowed) == 0 {
+		return fields
 	}
-	var fields []FieldInfo
-	if prefix != "" {
-		tt := target.Get("type").String()
-		if tt == "array" {
-			it := target.GetJson("items")
-			if it != nil {
-				if r := it.Get("$ref").String(); r != "" {
-					tt = "array(object)"
-				} else if it.Get("type").String() != "" {
-					tt = "array(" + it.Get("type").String() + ")"
-				} else {
-					tt = "array"
-				}
-			} else {
-				tt = "array"
-			}
-		} else if tt == "" {
-			tt = "object"
+	norm := make([]string, 0, len(allowed))
+	paths := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		if a == "" {
+			continue
 		}
-		desc := sj.Get("properties." + prefix + ".description").String()
-		fields = append(fields, FieldInfo{Path: prefix, Type: tt, Desc: desc})
+		norm = append(norm, normalizeFieldKey(a))
+		paths[normalizeFieldKey(a)] = struct{}{}
 	}
-	top := mergedProperties(j, sj)
-	if len(top) > 0 {
-		keysTop := make([]string, 0, len(top))
-		for k := range top {
-			if k != "data" {
-				keysTop = append(keysTop, k)
-			}
-		}
-		sortStrings(keysTop)
-		for _, k := range keysTop {
-			p := top[k]
-			t := p.Get("type").String()
-			if r := p.Get("$ref").String(); r != "" {
-				t = "object"
-			} else if t == "array" {
-				it0 := p.GetJson("items")
-				if it0 != nil {
-					if r0 := it0.Get("$ref").String(); r0 != "" {
-						t = "array(object)"
-					} else if it0.Get("type").String() != "" {
-						t = "array(" + it0.Get("type").String() + ")"
-					} else {
-						t = "array"
-					}
-				} else {
-					t = "array"
-				}
-			} else if t == "" {
-				t = "object"
-			}
-			fields = append(fields, FieldInfo{Path: k, Type: t, Desc: p.Get("description").String()})
+	out := make([]FieldInfo, 0, len(fields))
+	for _, f := range fields {
+		if f.Path == "code" || f.Path == "message" || f.Path == "data" {
+			out = append(out, f)
+			continue
 		}
-	}
-	if target.Get("type").String() == "array" {
-		it := target.GetJson("items")
-		if it != nil {
-			if r := it.Get("$ref").String(); r != "" {
-				sub := getRefJson(j, r)
-				if sub != nil {
-					for k, p := range mergedProperties(j, sub) {
-						t := p.Get("type").String()
-						if rr := p.Get("$ref").String(); rr != "" {
-							t = "object"
-						}
-						if t == "array" {
-							it2 := p.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									t = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									t = "array(" + it2.Get("type").String() + ")"
-								} else {
-									t = "array"
-								}
-							} else {
-								t = "array"
-							}
-						}
-						fields = append(fields, FieldInfo{Path: prefix + "[]." + k, Type: t, Desc: p.Get("description").String()})
-					}
-				}
-			} else if it.Get("type").String() == "object" {
-				props := mergedProperties(j, it)
-				keys := make([]string, 0, len(props))
-				for k := range props {
-					keys = append(keys, k)
-				}
-				sortStrings(keys)
-				for _, k := range keys {
-					p := props[k]
-					t := p.Get("type").String()
-					if rr := p.Get("$ref").String(); rr != "" {
-						t = "object"
-					}
-					if t == "array" {
-						it2 := p.GetJson("items")
-						if it2 != nil {
-							if r2 := it2.Get("$ref").String(); r2 != "" {
-								t = "array(object)"
-							} else if it2.Get("type").String() != "" {
-								t = "array(" + it2.Get("type").String() + ")"
-							} else {
-								t = "array"
-							}
-						} else {
-							t = "array"
-						}
-					} else if t == "" {
-						t = "object"
-					}
-					fields = append(fields, FieldInfo{Path: prefix + "[]." + k, Type: t, Desc: p.Get("description").String()})
-				}
-			} else {
-				tt := it.Get("type").String()
-				if tt != "" {
-					fields = append(fields, FieldInfo{Path: prefix + "[]", Type: "array(" + tt + ")", Desc: it.Get("description").String()})
-				}
+		keep := false
+		// 字段自身或其任一父字段命中完整路径时保留
+		for _, p := range fieldAncestors(f.Path) {
+			if matchAllowedPath(paths, p) {
+				keep = true
+				break
 			}
 		}
-	} else {
-		props := mergedProperties(j, target)
-		keys := make([]string, 0, len(props))
-		for k := range props {
-			keys = append(keys, k)
-		}
-		sortStrings(keys)
-		for _, k := range keys {
-			p := props[k]
-			cur := k
-			if prefix != "" {
-				cur = prefix + "." + k
-			}
-			t := p.Get("type").String()
-			if r := p.Get("$ref").String(); r != "" {
-				fields = append(fields, FieldInfo{Path: cur, Type: "object", Desc: p.Get("description").String()})
-				sub := getRefJson(j, r)
-				if sub != nil {
-					for sk, sp := range mergedProperties(j, sub) {
-						st := sp.Get("type").String()
-						if rr := sp.Get("$ref").String(); rr != "" {
-							st = "object"
-						}
-						if st == "array" {
-							it2 := sp.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									st = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									st = "array(" + it2.Get("type").String() + ")"
-								} else {
-									st = "array"
-								}
-							} else {
-								st = "array"
-							}
-						}
-						fields = append(fields, FieldInfo{Path: cur + "." + sk, Type: st, Desc: sp.Get("description").String()})
-					}
-				}
-				continue
+		for _, a := range norm {
+			if keep {
+				break
 			}
-			if t == "array" {
-				it := p.GetJson("items")
-				if it != nil {
-					if r2 := it.Get("$ref").String(); r2 != "" {
-						fields = append(fields, FieldInfo{Path: cur + "[]", Type: "array(object)", Desc: p.Get("description").String()})
-						sub := getRefJson(j, r2)
-						if sub != nil {
-							for sk, sp := range sub.GetJsonMap("properties") {
-								st := sp.Get("type").String()
-								if rr := sp.Get("$ref").String(); rr != "" {
-									st = "object"
-								}
-								if st == "array" {
-									it2 := sp.GetJson("items")
-									if it2 != nil {
-										if r3 := it2.Get("$ref").String(); r3 != "" {
-											st = "array(object)"
-										} else if it2.Get("type").String() != "" {
-											st = "array(" + it2.Get("type").String() + ")"
-										} else {
-											st = "array"
-										}
-									} else {
-										st = "array"
-									}
-								}
-								fields = append(fields, FieldInfo{Path: cur + "[]." + sk, Type: st, Desc: sp.Get("description").String()})
-							}
-						}
-						continue
-					}
-					if it.Get("type").String() == "object" {
-						props2 := it.GetJsonMap("properties")
-						k2 := make([]string, 0, len(props2))
-						for kk := range props2 {
-							k2 = append(k2, kk)
-						}
-						sortStrings(k2)
-						for _, kk := range k2 {
-							sp := props2[kk]
-							st := sp.Get("type").String()
-							if rr := sp.Get("$ref").String(); rr != "" {
-								st = "object"
-							}
-							if st == "array" {
-								it3 := sp.GetJson("items")
-								if it3 != nil {
-									if r3 := it3.Get("$ref").String(); r3 != "" {
-										st = "array(object)"
-									} else if it3.Get("type").String() != "" {
-										st = "array(" + it3.Get("type").String() + ")"
-									} else {
-										st = "array"
-									}
-								} else {
-									st = "array"
-								}
-							}
-							fields = append(fields, FieldInfo{Path: cur + "[]." + kk, Type: st, Desc: sp.Get("description").String()})
-						}
-						continue
+			// 字段名简写：命中 data 下同名的非对象字段
+			if strings.HasPrefix(f.Path, "data") && !strings.ContainsAny(a, ".[]") {
+				clean := strings.ReplaceAll(f.Path, "[]", "")
+				parts := strings.Split(clean, ".")
+				last := parts[len(parts)-1]
+				if last == a {
+					t := f.Type
+					if t != "object" && !strings.HasPrefix(t, "object(") {
+						keep = true
+						break
 					}
-					t = "array(" + it.Get("type").String() + ")"
-				} else {
-					t = "array"
 				}
-			} else if t == "" {
-				t = "object"
 			}
-			fields = append(fields, FieldInfo{Path: cur, Type: t, Desc: p.Get("description").String()})
+		}
+		if keep {
+			out = append(out, f)
 		}
 	}
-	fields = filterFieldInfos(fields, allowed)
-	var b strings.Builder
-	b.WriteString("<table><thead><tr><th>字段</th><th>类型</th><th>说明</th></tr></thead><tbody>")
-	if len(fields) == 0 {
-		b.WriteString("<tr><td colspan=3>无字段</td></tr>")
-	}
-	for _, f := range fields {
-		b.WriteString("<tr><td>" + htmlEscape(f.Path) + "</td><td>" + htmlEscape(sanitizeType(f.Type)) + "</td><td>" + htmlEscape(f.Desc) + "</td></tr>")
-	}
-	b.WriteString("</tbody></table>")
-	return b.String()
+	return out
 }
 
-// renderResponseParamFlatTableHTMLFromRef 渲染返回参数说明为 HTML（$ref）。
-func renderResponseParamFlatTableHTMLFromRef(j *gjson.Json, ref string) string {
-	sj := getSchema(j, componentNameFromRef(ref))
-	if sj == nil {
-		return ""
-	}
-	return renderResponseParamFlatTableHTMLFromJson(j, sj)
+// flattenSchemaFieldsFromJson 从内联 schema 展开到扁平行，包含 oneOf/anyOf 各变体的字段（路径前缀 "<变体名>"）；
+// view 为请求或返回视图时分别剔除 readOnly 与 writeOnly 属性。
+// 用于变更报告与质量检查；文档表格使用 flattenSchema 分别展示基础字段与变体。
+func flattenSchemaFieldsFromJson(j *gjson.Json, sj *gjson.Json, prefix string, view schemaView) []FieldInfo {
+	fields, groups := flattenSchema(j, sj, prefix, view)
+	return fieldsWithVariants(fields, groups)
 }
 
-// renderResponseParamFlatTableMarkdownFromJson 渲染返回参数说明为 Markdown（自动展开 data）。
-func renderResponseParamFlatTableMarkdownFromJson(j *gjson.Json, sj *gjson.Json) string {
-	target := sj
-	prefix := ""
-	for _, key := range []string{"data", "payload", "result", "content"} {
-		if !sj.Get("properties." + key).IsNil() {
-			d := sj.GetJson("properties." + key)
-			if r := d.Get("$ref").String(); r != "" {
-				target = getSchema(j, componentNameFromRef(r))
-			} else {
-				target = d
-			}
-			if target != sj {
-				prefix = key
-			}
-			break
-		}
+// flattenSchema 从内联 schema 展开到扁平行（请求/返回参数），并收集 oneOf/anyOf 变体分组。
+// 根节点为数组时以 "[]" 作为路径前缀展开其元素。
+func flattenSchema(j *gjson.Json, sj *gjson.Json, prefix string, view schemaView) ([]FieldInfo, []VariantGroup) {
+	if sj == nil {
+		return nil, nil
 	}
-	var fields []FieldInfo
-	if prefix != "" {
-		t := target.Get("type").String()
-		if t == "array" {
-			it := target.GetJson("items")
-			if it != nil {
-				if r := it.Get("$ref").String(); r != "" {
-					t = "array(object)"
-				} else if it.Get("type").String() != "" {
-					t = "array(" + it.Get("type").String() + ")"
-				} else {
-					t = "array"
-				}
-			} else {
-				t = "array"
-			}
-		} else if t == "" {
-			t = "object"
+	var groups []VariantGroup
+	if prefix == "" && schemaTypeName(sj) == "array" {
+		it := sj.GetJson("items")
+		if r := it.Get("$ref").String(); r != "" && isRefToObject(j, r) {
+			return flattenFields(j, getRefJson(j, r), "[]", view, map[string]bool{r: true}, &groups), groups
 		}
-		desc := sj.Get("properties." + prefix + ".description").String()
-		fields = append(fields, FieldInfo{Path: prefix, Type: t, Desc: desc})
-	}
-	top := mergedProperties(j, sj)
-	if len(top) > 0 {
-		keysTop := make([]string, 0, len(top))
-		for k := range top {
-			if prefix == "" || k != prefix {
-				keysTop = append(keysTop, k)
-			}
+		if isObjectSchema(it) {
+			return flattenFields(j, it, "[]", view, map[string]bool{}, &groups), groups
 		}
-		sortStrings(keysTop)
-		for _, k := range keysTop {
-			p := top[k]
-			t := p.Get("type").String()
-			if r := p.Get("$ref").String(); r != "" {
-				t = "object"
-			}
-			if t == "array" {
-				it0 := p.GetJson("items")
-				if it0 != nil {
-					if r0 := it0.Get("$ref").String(); r0 != "" {
-						t = "array(object)"
-					} else if it0.Get("type").String() != "" {
-						t = "array(" + it0.Get("type").String() + ")"
-					} else {
-						t = "array"
-					}
-				} else {
-					t = "array"
-				}
-			} else if t == "" {
-				t = "object"
-			}
-			fields = append(fields, FieldInfo{Path: k, Type: t, Desc: p.Get("description").String()})
+		if t := leafTypeName(j, it); t != "" {
+			return []FieldInfo{{Path: "[]", Type: "array(" + t + ")", Desc: titleDescription(it), Constraints: arrayConstraints(j, sj, it)}}, nil
 		}
+		return nil, nil
 	}
-	if target.Get("type").String() == "array" {
-		it := target.GetJson("items")
-		if it != nil {
-			if r := it.Get("$ref").String(); r != "" {
-				sub := getRefJson(j, r)
-				if sub != nil {
-					for k, p := range mergedProperties(j, sub) {
-						t := p.Get("type").String()
-						if rr := p.Get("$ref").String(); rr != "" {
-							t = "object"
-						}
-						if t == "array" {
-							it2 := p.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									t = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									t = "array(" + it2.Get("type").String() + ")"
-								} else {
-									t = "array"
-								}
-							} else {
-								t = "array"
-							}
-						}
-						fields = append(fields, FieldInfo{Path: prefix + "[]." + k, Type: t, Desc: p.Get("description").String()})
-					}
-				}
-			} else if it.Get("type").String() == "object" {
-				props := mergedProperties(j, it)
-				keys := make([]string, 0, len(props))
-				for k := range props {
-					keys = append(keys, k)
-				}
-				sortStrings(keys)
-				for _, k := range keys {
-					p := props[k]
-					t := p.Get("type").String()
-					if rr := p.Get("$ref").String(); rr != "" {
-						t = "object"
-					}
-					if t == "array" {
-						it2 := p.GetJson("items")
-						if it2 != nil {
-							if r2 := it2.Get("$ref").String(); r2 != "" {
-								t = "array(object)"
-							} else if it2.Get("type").String() != "" {
-								t = "array(" + it2.Get("type").String() + ")"
-							} else {
-								t = "array"
-							}
-						} else {
-							t = "array"
-						}
-					} else if t == "" {
-						t = "object"
-					}
-					fields = append(fields, FieldInfo{Path: prefix + "[]." + k, Type: t, Desc: p.Get("description").String()})
-				}
-			} else {
-				tt := it.Get("type").String()
-				if tt != "" {
-					fields = append(fields, FieldInfo{Path: prefix + "[]", Type: "array(" + tt + ")", Desc: it.Get("description").String()})
-				}
-			}
-		}
-	} else {
-		props := mergedProperties(j, target)
-		keys := make([]string, 0, len(props))
-		for k := range props {
-			keys = append(keys, k)
-		}
-		sortStrings(keys)
-		for _, k := range keys {
-			p := props[k]
-			cur := k
-			if prefix != "" {
-				cur = prefix + "." + k
-			}
-			t := p.Get("type").String()
-			if r := p.Get("$ref").String(); r != "" {
-				fields = append(fields, FieldInfo{Path: cur, Type: "object", Desc: p.Get("description").String()})
-				sub := getRefJson(j, r)
-				if sub != nil {
-					for sk, sp := range mergedProperties(j, sub) {
-						st := sp.Get("type").String()
-						if rr := sp.Get("$ref").String(); rr != "" {
-							st = "object"
-						}
-						if st == "array" {
-							it2 := sp.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									st = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									st = "array(" + it2.Get("type").String() + ")"
-								} else {
-									st = "array"
-								}
-							} else {
-								st = "array"
-							}
-						}
-						fields = append(fields, FieldInfo{Path: cur + "." + sk, Type: st, Desc: sp.Get("description").String()})
-					}
-				}
-				continue
-			}
-			if t == "array" {
-				it := p.GetJson("items")
-				if it != nil {
-					if r2 := it.Get("$ref").String(); r2 != "" {
-						fields = append(fields, FieldInfo{Path: cur + "[]", Type: "array(object)", Desc: p.Get("description").String()})
-						sub := getRefJson(j, r2)
-						if sub != nil {
-							for sk, sp := range mergedProperties(j, sub) {
-								st := sp.Get("type").String()
-								if rr := sp.Get("$ref").String(); rr != "" {
-									st = "object"
-								}
-								if st == "array" {
-									it2 := sp.GetJson("items")
-									if it2 != nil {
-										if r3 := it2.Get("$ref").String(); r3 != "" {
-											st = "array(object)"
-										} else if it2.Get("type").String() != "" {
-											st = "array(" + it2.Get("type").String() + ")"
-										} else {
-											st = "array"
-										}
-									} else {
-										st = "array"
-									}
-								}
-								fields = append(fields, FieldInfo{Path: cur + "[]." + sk, Type: st, Desc: sp.Get("description").String()})
-							}
-						}
-						continue
-					}
-					if it.Get("type").String() == "object" {
-						props2 := mergedProperties(j, it)
-						k2 := make([]string, 0, len(props2))
-						for kk := range props2 {
-							k2 = append(k2, kk)
-						}
-						sortStrings(k2)
-						for _, kk := range k2 {
-							sp := props2[kk]
-							st := sp.Get("type").String()
-							if rr := sp.Get("$ref").String(); rr != "" {
-								st = "object"
-							}
-							if st == "array" {
-								it3 := sp.GetJson("items")
-								if it3 != nil {
-									if r3 := it3.Get("$ref").String(); r3 != "" {
-										st = "array(object)"
-									} else if it3.Get("type").String() != "" {
-										st = "array(" + it3.Get("type").String() + ")"
-									} else {
-										st = "array"
-									}
-								} else {
-									st = "array"
-								}
-							}
-							fields = append(fields, FieldInfo{Path: cur + "[]." + kk, Type: st, Desc: sp.Get("description").String()})
-						}
-						continue
-					}
-					t = "array(" + it.Get("type").String() + ")"
-				} else {
-					t = "array"
-				}
-			} else if t == "" {
-				t = "object"
-			}
-			fields = append(fields, FieldInfo{Path: cur, Type: t, Desc: p.Get("description").String()})
+	return flattenFields(j, sj, prefix, view, map[string]bool{}, &groups), groups
+}
+
+// responseEnvelopeKeys 返回体中承载业务数据的包装字段，按优先级排列。
+var responseEnvelopeKeys = []string{"data", "payload", "result", "content"}
+
+// envelopeFirst 调整返回参数表的行序：包装字段（responseEnvelopeKeys 中首个存在者）的行居首，
+// 其次为其余顶层字段（code、message 等）及其子字段，最后为包装字段的子字段；各部分内部保持原有顺序。
+// 返回体没有包装字段时原样返回。
+func envelopeFirst(j *gjson.Json, s *gjson.Json, fields []FieldInfo, view schemaView) []FieldInfo {
+	props, _ := mergedObject(j, s, view, map[string]bool{})
+	key := ""
+	for _, k := range responseEnvelopeKeys {
+		if _, ok := props[k]; ok {
+			key = k
+			break
 		}
 	}
-	var b2 strings.Builder
-	b2.WriteString("<table><thead><tr><th>字段</th><th>类型</th><th>说明</th></tr></thead><tbody>")
-	if len(fields) == 0 {
-		b2.WriteString("<tr><td colspan=3>无字段</td></tr>")
+	if key == "" {
+		return fields
 	}
+	res := make([]FieldInfo, 0, len(fields))
+	var rest, children []FieldInfo
 	for _, f := range fields {
-		b2.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td></tr>", htmlEscape(f.Path), htmlEscape(sanitizeType(f.Type)), htmlEscape(f.Desc)))
+		switch {
+		case f.Path == key || f.Path == key+"[]":
+			res = append(res, f)
+		case strings.HasPrefix(f.Path, key+".") || strings.HasPrefix(f.Path, key+"[]"):
+			children = append(children, f)
+		default:
+			rest = append(rest, f)
+		}
 	}
-	b2.WriteString("</tbody></table>")
-	return b2.String()
+	res = append(res, rest...)
+	return append(res, children...)
 }
 
-// renderResponseParamFlatTableMarkdownFromRef 渲染返回参数说明为 Markdown（$ref）。
-func renderResponseParamFlatTableMarkdownFromRef(j *gjson.Json, ref string) string {
-	sj := getSchema(j, componentNameFromRef(ref))
-	if sj == nil {
-		return ""
-	}
-	return renderResponseParamFlatTableMarkdownFromJson(j, sj)
+// isRefToObject 判断引用是否指向对象组件；无法解析的引用按对象处理（保持原有展示）。
+func isRefToObject(j *gjson.Json, ref string) bool {
+	sub := getRefJson(j, ref)
+	return sub == nil || isObjectSchema(sub)
 }
 
-// renderResponseParamFlatTableMarkdownFromJsonWithAllowed 渲染“返回参数说明”为 Markdown 表格（内联 schema），
-// 首先输出容器行 data，随后展开并按 allowed 白名单过滤保留的 data 子字段；
-// 顶层字段 code/message/data 始终保留。
-func renderResponseParamFlatTableMarkdownFromJsonWithAllowed(j *gjson.Json, sj *gjson.Json, allowed []string) string {
-	target := sj
-	if !sj.Get("properties.data").IsNil() {
-		d := sj.GetJson("properties.data")
-		if r := d.Get("$ref").String(); r != "" {
-			target = getSchema(j, componentNameFromRef(r))
-		} else {
-			target = d
-		}
-	}
-	prefix := ""
-	if target != sj {
-		prefix = "data"
-	}
-	var fields []FieldInfo
-	if prefix != "" {
-		t := target.Get("type").String()
-		if t == "array" {
-			it := target.GetJson("items")
-			if it != nil {
-				if r := it.Get("$ref").String(); r != "" {
-					t = "array(object)"
-				} else if it.Get("type").String() != "" {
-					t = "array(" + it.Get("type").String() + ")"
-				} else {
-					t = "array"
-				}
-			} else {
-				t = "array"
-			}
-		} else if t == "" {
-			t = "object"
+// leafTypeName 返回基础类型 schema 的类型名，$ref 指向基础类型组件时取组件的类型。
+func leafTypeName(j *gjson.Json, s *gjson.Json) string {
+	if r := s.Get("$ref").String(); r != "" {
+		if sub := getRefJson(j, r); sub != nil {
+			return schemaTypeName(sub)
 		}
-		desc := sj.Get("properties." + prefix + ".description").String()
-		fields = append(fields, FieldInfo{Path: prefix, Type: t, Desc: desc})
 	}
-	top := sj.GetJsonMap("properties")
-	if len(top) > 0 {
-		keysTop := make([]string, 0, len(top))
-		for k := range top {
-			if k != "data" {
-				keysTop = append(keysTop, k)
-			}
-		}
-		sortStrings(keysTop)
-		for _, k := range keysTop {
-			p := top[k]
-			t := p.Get("type").String()
-			if r := p.Get("$ref").String(); r != "" {
-				t = "object"
-			} else if t == "array" {
-				it0 := p.GetJson("items")
-				if it0 != nil {
-					if r0 := it0.Get("$ref").String(); r0 != "" {
-						t = "array(object)"
-					} else if it0.Get("type").String() != "" {
-						t = "array(" + it0.Get("type").String() + ")"
-					} else {
-						t = "array"
-					}
-				} else {
-					t = "array"
-				}
-			} else if t == "" {
-				t = "object"
-			}
-			fields = append(fields, FieldInfo{Path: k, Type: t, Desc: p.Get("description").String()})
-		}
-	}
-	if target.Get("type").String() == "array" {
-		it := target.GetJson("items")
-		if it != nil {
-			if r := it.Get("$ref").String(); r != "" {
-				sub := getRefJson(j, r)
-				if sub != nil {
-					for k, p := range sub.GetJsonMap("properties") {
-						t := p.Get("type").String()
-						if rr := p.Get("$ref").String(); rr != "" {
-							t = "object"
-						}
-						if t == "array" {
-							it2 := p.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									t = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									t = "array(" + it2.Get("type").String() + ")"
-								} else {
-									t = "array"
-								}
-							} else {
-								t = "array"
-							}
-						}
-						fields = append(fields, FieldInfo{Path: prefix + "[]." + k, Type: t, Desc: p.Get("description").String()})
-					}
-				}
-			} else if it.Get("type").String() == "object" {
-				props := it.GetJsonMap("properties")
-				keys := make([]string, 0, len(props))
-				for k := range props {
-					keys = append(keys, k)
-				}
-				sortStrings(keys)
-				for _, k := range keys {
-					p := props[k]
-					t := p.Get("type").String()
-					if rr := p.Get("$ref").String(); rr != "" {
-						t = "object"
-					}
-					if t == "array" {
-						it2 := p.GetJson("items")
-						if it2 != nil {
-							if r2 := it2.Get("$ref").String(); r2 != "" {
-								t = "array(object)"
-							} else if it2.Get("type").String() != "" {
-								t = "array(" + it2.Get("type").String() + ")"
-							} else {
-								t = "array"
-							}
-						} else {
-							t = "array"
-						}
-					} else if t == "" {
-						t = "object"
-					}
-					fields = append(fields, FieldInfo{Path: prefix + "[]." + k, Type: t, Desc: p.Get("description").String()})
-				}
-			} else {
-				tt := it.Get("type").String()
-				if tt != "" {
-					fields = append(fields, FieldInfo{Path: prefix + "[]", Type: "array(" + tt + ")", Desc: it.Get("description").String()})
-				}
-			}
-		}
-	} else {
-		props := target.GetJsonMap("properties")
-		keys := make([]string, 0, len(props))
-		for k := range props {
-			keys = append(keys, k)
-		}
-		sortStrings(keys)
-		for _, k := range keys {
-			p := props[k]
-			cur := k
-			if prefix != "" {
-				cur = prefix + "." + k
-			}
-			t := p.Get("type").String()
-			if r := p.Get("$ref").String(); r != "" {
-				fields = append(fields, FieldInfo{Path: cur, Type: "object", Desc: p.Get("description").String()})
-				sub := getRefJson(j, r)
-				if sub != nil {
-					for sk, sp := range sub.GetJsonMap("properties") {
-						st := sp.Get("type").String()
-						if rr := sp.Get("$ref").String(); rr != "" {
-							st = "object"
-						}
-						if st == "array" {
-							it2 := sp.GetJson("items")
-							if it2 != nil {
-								if r2 := it2.Get("$ref").String(); r2 != "" {
-									st = "array(object)"
-								} else if it2.Get("type").String() != "" {
-									st = "array(" + it2.Get("type").String() + ")"
-								} else {
-									st = "array"
-								}
-							} else {
-								st = "array"
-							}
-						}
-						fields = append(fields, FieldInfo{Path: cur + "." + sk, Type: st, Desc: sp.Get("description").String()})
-					}
-				}
-				continue
-			}
-			if t == "array" {
-				it := p.GetJson("items")
-				if it != nil {
-					if r2 := it.Get("$ref").String(); r2 != "" {
-						fields = append(fields, FieldInfo{Path: cur + "[]", Type: "array(object)", Desc: p.Get("description").String()})
-						sub := getRefJson(j, r2)
-						if sub != nil {
-							for sk, sp := range sub.GetJsonMap("properties") {
-								st := sp.Get("type").String()
-								if rr := sp.Get("$ref").String(); rr != "" {
-									st = "object"
-								}
-								if st == "array" {
-									it2 := sp.GetJson("items")
-									if it2 != nil {
-										if r3 := it2.Get("$ref").String(); r3 != "" {
-											st = "array(object)"
-										} else if it2.Get("type").String() != "" {
-											st = "array(" + it2.Get("type").String() + ")"
-										} else {
-											st = "array"
-										}
-									} else {
-										st = "array"
-									}
-								}
-								fields = append(fields, FieldInfo{Path: cur + "[]." + sk, Type: st, Desc: sp.Get("description").String()})
-							}
-						}
-						continue
-					}
-					if it.Get("type").String() == "object" {
-						props2 := it.GetJsonMap("properties")
-						k2 := make([]string, 0, len(props2))
-						for kk := range props2 {
-							k2 = append(k2, kk)
-						}
-						sortStrings(k2)
-						for _, kk := range k2 {
-							sp := props2[kk]
-							st := sp.Get("type").String()
-							if rr := sp.Get("$ref").String(); rr != "" {
-								st = "object"
-							}
-							if st == "array" {
-								it3 := sp.GetJson("items")
-								if it3 != nil {
-									if r3 := it3.Get("$ref").String(); r3 != "" {
-										st = "array(object)"
-									} else if it3.Get("type").String() != "" {
-										st = "array(" + it3.Get("type").String() + ")"
-									} else {
-										st = "array"
-									}
-								} else {
-									st = "array"
-								}
-							}
-							fields = append(fields, FieldInfo{Path: cur + "[]." + kk, Type: st, Desc: sp.Get("description").String()})
-						}
-						continue
-					}
-					t = "array(" + it.Get("type").String() + ")"
-				} else {
-					t = "array"
-				}
-			} else if t == "" {
-				t = "object"
-			}
-			fields = append(fields, FieldInfo{Path: cur, Type: t, Desc: p.Get("description").String()})
-		}
-	}
-	fields = filterFieldInfos(fields, allowed)
-	var b strings.Builder
-	b.WriteString("| 字段 | 类型 | 说明 |\n|---|---|---|\n")
-	if len(fields) == 0 {
-		b.WriteString("| 无字段 |  |  |\n")
-	}
-	for _, f := range fields {
-		b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", f.Path, sanitizeType(f.Type), f.Desc))
+	return schemaTypeName(s)
+}
+
+// isObjectSchema 判断内联 schema 是否为对象（显式 object、含 properties、映射值定义或组合关键字）。
+func isObjectSchema(s *gjson.Json) bool {
+	if s == nil {
+		return false
 	}
-	return b.String()
+	return s.Get("type").String() == "object" || len(s.GetJsonMap("properties")) > 0 ||
+		s.Get("additionalProperties").IsMap() || len(s.GetJsonMap("patternProperties")) > 0 ||
+		len(s.Get("allOf").Array()) > 0 || len(s.Get("oneOf").Array()) > 0 || len(s.Get("anyOf").Array()) > 0
 }
 
-// flattenSchemaFields 基于 $ref 展开对象的所有子字段到扁平行（请求参数）。
-func flattenSchemaFields(j *gjson.Json, ref string, prefix string) []FieldInfo {
-	name := componentNameFromRef(ref)
-	sj := getSchema(j, name)
+// flattenFields 递归展开对象属性；seen 记录当前展开链路上的 $ref，遇到循环引用时只输出该行而不再深入。
+// allOf 的属性与 required 合并展开；oneOf/anyOf 以出现位置为前缀收集到 groups，不并入基础字段。
+func flattenFields(j *gjson.Json, sj *gjson.Json, prefix string, view schemaView, seen map[string]bool, groups *[]VariantGroup) []FieldInfo {
 	if sj == nil {
 		return nil
 	}
 	fields := make([]FieldInfo, 0, 16)
-	props := sj.GetJsonMap("properties")
-	requiredSet := setFromArray(sj.Get("required").Array())
+	props, requiredSet := mergedObject(j, sj, view, map[string]bool{})
 	keys := make([]string, 0, len(props))
 	for k := range props {
 		keys = append(keys, k)
 	}
 	sortStrings(keys)
-	for _, k := range keys {
-		pj := props[k]
-		req := requiredSet[k]
-		typ := pj.Get("type").String()
+	// descend 展开引用的子 schema，并维护循环检测链路
+	descend := func(ref string, path string) {
+		if seen[ref] {
+			return
+		}
+		sub := getRefJson(j, ref)
+		if sub == nil {
+			return
+		}
+		seen[ref] = true
+		fields = append(fields, flattenFields(j, sub, path, view, seen, groups)...)
+		delete(seen, ref)
+	}
+	// emit 输出单个字段行，对象与对象数组继续展开
+	emit := func(curPath string, req bool, pj *gjson.Json) {
+		// 废弃标记写入本字段行（各分支首先追加的一行）
+		start := len(fields)
+		defer func() {
+			if len(fields) > start {
+				fields[start].Deprecation = deprecationOf(j, pj)
+			}
+		}()
+		typ := schemaTypeName(pj)
 		desc := titleDescription(pj)
 		ref2 := pj.Get("$ref").String()
-		curPath := k
-		if prefix != "" {
-			curPath = prefix + "." + k
-		}
 		if typ == "array" {
 			it := pj.GetJson("items")
-			if it != nil {
-				if r := it.Get("$ref").String(); r != "" {
-					fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array(object)", Desc: desc})
-					sub := flattenSchemaFields(j, r, curPath+"[]")
-					fields = append(fields, sub...)
-					continue
+			if !it.IsNil() {
+				if r := it.Get("$ref").String(); r != "" && isRefToObject(j, r) {
+					fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: objectArrayType(j, it), Desc: desc, Constraints: arrayConstraints(j, pj, nil)})
+					descend(r, curPath+"[]")
+					return
 				}
-				inlineObj := it.Get("type").String() == "object" || len(it.GetJsonMap("properties")) > 0 || len(it.Get("allOf").Array()) > 0 || len(it.Get("oneOf").Array()) > 0 || len(it.Get("anyOf").Array()) > 0
-				if inlineObj {
-					fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array(object)", Desc: desc})
-					props2 := mergedProperties(j, it)
-					itemReq := setFromArray(it.Get("required").Array())
-					k2 := make([]string, 0, len(props2))
-					for kk := range props2 {
-						k2 = append(k2, kk)
-					}
-					sortStrings(k2)
-					for _, kk := range k2 {
-						sp := props2[kk]
-						st := sp.Get("type").String()
-						if rr := sp.Get("$ref").String(); rr != "" {
-							st = "object"
-						}
-						if st == "array" {
-							it3 := sp.GetJson("items")
-							if it3 != nil {
-								if r3 := it3.Get("$ref").String(); r3 != "" {
-									st = "array(object)"
-								} else if it3.Get("type").String() != "" {
-									st = "array(" + it3.Get("type").String() + ")"
-								} else {
-									st = "array"
-								}
-							} else {
-								st = "array"
-							}
-						} else if st == "" {
-							st = "object"
-						}
-						fields = append(fields, FieldInfo{Path: curPath + "[]." + kk, Required: itemReq[kk], Type: st, Desc: titleDescription(sp)})
-					}
-					continue
+				if isObjectSchema(it) {
+					fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: objectArrayType(j, it), Desc: desc, Constraints: arrayConstraints(j, pj, nil)})
+					fields = append(fields, flattenFields(j, it, curPath+"[]", view, seen, groups)...)
+					return
 				}
-				fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array(" + it.Get("type").String() + ")", Desc: desc})
-				continue
+				fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array(" + leafTypeName(j, it) + ")", Desc: desc, Constraints: arrayConstraints(j, pj, it)})
+				return
 			}
-			fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array", Desc: desc})
-			continue
+			fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array", Desc: desc, Constraints: schemaConstraints(j, pj)})
+			return
 		}
 		if ref2 != "" {
-			fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: "object", Desc: desc})
-			sub := flattenSchemaFields(j, ref2, curPath)
-			fields = append(fields, sub...)
-			continue
-		}
-		if typ == "object" {
-			fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: "object", Desc: desc})
-			nestedProps := pj.GetJsonMap("properties")
-			if len(nestedProps) > 0 {
-				nestedReq := setFromArray(pj.Get("required").Array())
-				nkeys := make([]string, 0, len(nestedProps))
-				for nk := range nestedProps {
-					nkeys = append(nkeys, nk)
-				}
-				sortStrings(nkeys)
-				for _, nk := range nkeys {
-					np := nestedProps[nk]
-					nreq := nestedReq[nk]
-					ntyp := np.Get("type").String()
-					ndesc := titleDescription(np)
-					nref := np.Get("$ref").String()
-					npath := curPath + "." + nk
-					if ntyp == "array" {
-						it := np.GetJson("items")
-						if it != nil {
-							if r := it.Get("$ref").String(); r != "" {
-								fields = append(fields, FieldInfo{Path: npath + "[]", Required: nreq, Type: "array(object)", Desc: ndesc})
-								sub := flattenSchemaFields(j, r, npath+"[]")
-								fields = append(fields, sub...)
-								continue
-							}
-							fields = append(fields, FieldInfo{Path: npath + "[]", Required: nreq, Type: "array(" + it.Get("type").String() + ")", Desc: ndesc})
-							continue
-						}
-						fields = append(fields, FieldInfo{Path: npath + "[]", Required: nreq, Type: "array", Desc: ndesc})
-						continue
-					}
-					if nref != "" {
-						fields = append(fields, FieldInfo{Path: npath, Required: nreq, Type: "object", Desc: ndesc})
-						sub := flattenSchemaFields(j, nref, npath)
-						fields = append(fields, sub...)
-						continue
-					}
-					fields = append(fields, FieldInfo{Path: npath, Required: nreq, Type: ntyp, Desc: ndesc})
+			sub := getRefJson(j, ref2)
+			// 引用基础类型组件（如枚举）时按叶子字段展示其类型与约束
+			if sub != nil && !isObjectSchema(sub) && schemaTypeName(sub) != "array" {
+				if desc == "" {
+					desc = titleDescription(sub)
 				}
+				fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: schemaTypeName(sub), Desc: desc, Constraints: schemaConstraints(j, pj)})
+				return
 			}
-			continue
+			fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: objectRowType(j, pj), Desc: desc})
+			descend(ref2, curPath)
+			return
 		}
-		fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: typ, Desc: desc})
-	}
-	return fields
-}
-
-// flattenSchemaFieldsFromJson 从内联 schema 展开到扁平行（请求/返回参数）。
-func flattenSchemaFieldsFromJson(j *gjson.Json, sj *gjson.Json, prefix string) []FieldInfo {
-	if sj == nil {
-		return nil
-	}
-	fields := make([]FieldInfo, 0, 16)
-	props := sj.GetJsonMap("properties")
-	if len(props) == 0 {
-		for _, arrName := range []string{"allOf", "oneOf", "anyOf"} {
-			for _, it := range sj.Get(arrName).Array() {
-				elem := gjson.New(it)
-				if r := elem.Get("$ref").String(); r != "" {
-					sub := getRefJson(j, r)
-					if sub != nil {
-						for k, v := range sub.GetJsonMap("properties") {
-							props[k] = v
-						}
-					}
-				} else {
-					for k, v := range elem.GetJsonMap("properties") {
-						props[k] = v
-					}
-				}
-			}
+		if typ == "object" || (typ == "" && isObjectSchema(pj)) {
+			fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: objectRowType(j, pj), Desc: desc})
+			fields = append(fields, flattenFields(j, pj, curPath, view, seen, groups)...)
+			return
 		}
+		fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: typ, Desc: desc, Constraints: schemaConstraints(j, pj)})
 	}
-	requiredSet := setFromArray(sj.Get("required").Array())
-	keys := make([]string, 0, len(props))
-	for k := range props {
-		keys = append(keys, k)
-	}
-	sortStrings(keys)
 	for _, k := range keys {
-		pj := props[k]
-		req := requiredSet[k]
-		typ := pj.Get("type").String()
-		desc := titleDescription(pj)
-		ref2 := pj.Get("$ref").String()
-		curPath := k
-		if prefix != "" {
-			curPath = prefix + "." + k
-		}
-		if typ == "array" {
-			it := pj.GetJson("items")
-			if it != nil {
-				if r := it.Get("$ref").String(); r != "" {
-					fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array(object)", Desc: desc})
-					sub := getRefJson(j, r)
-					if sub != nil {
-						fields = append(fields, flattenSchemaFieldsFromJson(j, sub, curPath+"[]")...)
-					}
-					continue
-				}
-				inlineObj := it.Get("type").String() == "object" || len(it.GetJsonMap("properties")) > 0 || len(it.Get("allOf").Array()) > 0 || len(it.Get("oneOf").Array()) > 0 || len(it.Get("anyOf").Array()) > 0
-				if inlineObj {
-					fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array(object)", Desc: desc})
-					props2 := mergedProperties(j, it)
-					itemReq := setFromArray(it.Get("required").Array())
-					k2 := make([]string, 0, len(props2))
-					for kk := range props2 {
-						k2 = append(k2, kk)
-					}
-					sortStrings(k2)
-					for _, kk := range k2 {
-						sp := props2[kk]
-						st := sp.Get("type").String()
-						if rr := sp.Get("$ref").String(); rr != "" {
-							st = "object"
-						}
-						if st == "array" {
-							it3 := sp.GetJson("items")
-							if it3 != nil {
-								if r3 := it3.Get("$ref").String(); r3 != "" {
-									st = "array(object)"
-								} else if it3.Get("type").String() != "" {
-									st = "array(" + it3.Get("type").String() + ")"
-								} else {
-									st = "array"
-								}
-							} else {
-								st = "array"
-							}
-						} else if st == "" {
-							st = "object"
-						}
-						fields = append(fields, FieldInfo{Path: curPath + "[]." + kk, Required: itemReq[kk], Type: st, Desc: titleDescription(sp)})
-					}
-					continue
-				}
-				fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array(" + it.Get("type").String() + ")", Desc: desc})
-				continue
-			}
-			fields = append(fields, FieldInfo{Path: curPath + "[]", Required: req, Type: "array", Desc: desc})
-			continue
-		}
-		if ref2 != "" {
-			fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: "object", Desc: desc})
-			sub := getRefJson(j, ref2)
-			if sub != nil {
-				fields = append(fields, flattenSchemaFieldsFromJson(j, sub, curPath)...)
-			}
-			continue
+		emit(pathJoin(prefix, k), requiredSet[k], props[k])
+	}
+	// additionalProperties/patternProperties：值 schema 以 {key} 段展开
+	for _, e := range mapEntries(sj) {
+		if len(e.Schema.Map()) == 0 {
+			continue // additionalProperties: true 或 {}：值不限类型，无可展开内容
 		}
-		if typ == "object" {
-			fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: "object", Desc: desc})
-			fields = append(fields, flattenSchemaFieldsFromJson(j, pj, curPath)...)
-			continue
+		emit(pathJoin(prefix, e.Segment), false, e.Schema)
+	}
+	// oneOf/anyOf：逐个变体展开字段，变体内的多态继续追加到 groups
+	if g := variantGroupOf(j, sj, prefix); g != nil && groups != nil {
+		idx := len(*groups)
+		*groups = append(*groups, *g)
+		for i, v := range g.Variants {
+			vf := variantFields(j, g, v, view, seen, groups)
+			(*groups)[idx].Variants[i].Fields = vf
 		}
-		fields = append(fields, FieldInfo{Path: curPath, Required: req, Type: typ, Desc: desc})
 	}
 	return fields
 }
@@ -0,0 +1,408 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// SecuritySchemeInfo components.securitySchemes 中的认证方式。
+// - Type: apiKey/http/oauth2/openIdConnect/mutualTLS
+// - In/ParamName: apiKey 的位置（header/query/cookie）与参数名
+// - Scheme/BearerFormat: http 的认证方案（bearer/basic 等）与令牌格式
+// - Flows: oauth2 的授权流程（按 implicit、password、clientCredentials、authorizationCode 顺序）
+type SecuritySchemeInfo struct {
+	Name             string
+	Type             string
+	Description      string
+	In               string
+	ParamName        string
+	Scheme           string
+	BearerFormat     string
+	OpenIDConnectURL string
+	Flows            []OAuthFlowInfo
+}
+
+// OAuthFlowInfo oauth2 的单个授权流程及其权限范围。
+type OAuthFlowInfo struct {
+	Type             string
+	AuthorizationURL string
+	TokenURL         string
+	RefreshURL       string
+	Scopes           []ScopeInfo
+}
+
+// ScopeInfo oauth2 权限范围及说明。
+type ScopeInfo struct {
+	Name string
+	Desc string
+}
+
+// SecurityRequirement security 数组中的一项：Schemes 需同时满足；多项之间任选其一。
+// Schemes 为空表示可匿名访问（security 中的 {}）。
+type SecurityRequirement struct {
+	Schemes []SecurityRef
+}
+
+// SecurityRef 认证要求中引用的认证方式及所需权限范围；Scheme 为 nil 表示未在 securitySchemes 中声明。
+type SecurityRef struct {
+	Name   string
+	Scopes []string
+	Scheme *SecuritySchemeInfo
+}
+
+// oauthFlowOrder oauth2 授权流程的展示顺序。
+var oauthFlowOrder = []string{"implicit", "password", "clientCredentials", "authorizationCode"}
+
+// securitySchemes 解析 components.securitySchemes（兼容 $ref），按名称排序。
+func securitySchemes(j *gjson.Json) []SecuritySchemeInfo {
+	sm := j.GetJsonMap("components.securitySchemes")
+	names := make([]string, 0, len(sm))
+	for k := range sm {
+		names = append(names, k)
+	}
+	sortStrings(names)
+	res := make([]SecuritySchemeInfo, 0, len(names))
+	for _, name := range names {
+		s := sm[name]
+		if r := s.Get("$ref").String(); r != "" {
+			if s = getRefJson(j, r); s == nil {
+				continue
+			}
+		}
+		info := SecuritySchemeInfo{
+			Name:             name,
+			Type:             s.Get("type").String(),
+			Description:      strings.TrimSpace(s.Get("description").String()),
+			In:               s.Get("in").String(),
+			ParamName:        s.Get("name").String(),
+			Scheme:           strings.ToLower(s.Get("scheme").String()),
+			BearerFormat:     s.Get("bearerFormat").String(),
+			OpenIDConnectURL: s.Get("openIdConnectUrl").String(),
+		}
+		for _, ft := range oauthFlowOrder {
+			fj := s.GetJson("flows." + ft)
+			if fj == nil || fj.IsNil() {
+				continue
+			}
+			flow := OAuthFlowInfo{
+				Type:             ft,
+				AuthorizationURL: fj.Get("authorizationUrl").String(),
+				TokenURL:         fj.Get("tokenUrl").String(),
+				RefreshURL:       fj.Get("refreshUrl").String(),
+			}
+			scopes := fj.Get("scopes").MapStrStr()
+			keys := make([]string, 0, len(scopes))
+			for k := range scopes {
+				keys = append(keys, k)
+			}
+			sortStrings(keys)
+			for _, k := range keys {
+				flow.Scopes = append(flow.Scopes, ScopeInfo{Name: k, Desc: scopes[k]})
+			}
+			info.Flows = append(info.Flows, flow)
+		}
+		res = append(res, info)
+	}
+	return res
+}
+
+// effectiveSecurity 返回接口生效的认证要求：接口级 security 优先，其次为文档级 security。
+// declared 为 false 表示两级均未声明；声明为空数组（security: []）时 declared 为 true 且 reqs 为空，即公开接口。
+func effectiveSecurity(j *gjson.Json, op *gjson.Json, schemes []SecuritySchemeInfo) (reqs []SecurityRequirement, declared bool) {
+	src := op.Get("security")
+	if src.IsNil() {
+		src = j.Get("security")
+	}
+	if src.IsNil() {
+		return nil, false
+	}
+	byName := make(map[string]*SecuritySchemeInfo, len(schemes))
+	for i := range schemes {
+		byName[schemes[i].Name] = &schemes[i]
+	}
+	for _, it := range src.Array() {
+		m := gjson.New(it).Map()
+		names := make([]string, 0, len(m))
+		for k := range m {
+			names = append(names, k)
+		}
+		sortStrings(names)
+		var req SecurityRequirement
+		for _, k := range names {
+			req.Schemes = append(req.Schemes, SecurityRef{Name: k, Scopes: jsonArrayStrings(gjson.New(m[k]).Array()), Scheme: byName[k]})
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, true
+}
+
+// schemeHeaderName 返回经由请求头传递的认证方式所用的 Header 名（apiKey in header 或 http 认证的 Authorization）；其他方式返回空串。
+func schemeHeaderName(s *SecuritySchemeInfo) string {
+	switch {
+	case s == nil:
+		return ""
+	case s.Type == "apiKey" && s.In == "header":
+		return s.ParamName
+	case s.Type == "http":
+		return "Authorization"
+	}
+	return ""
+}
+
+// securityHeaders 将生效认证要求中基于 Header 的认证方式转为 Header 参数；
+// 仅当每个可选项都包含该 Header 时标记为必选。
+func securityHeaders(reqs []SecurityRequirement, loc Locale) []ParamInfo {
+	var res []ParamInfo
+	index := make(map[string]int)
+	count := make(map[string]int)
+	for _, req := range reqs {
+		inReq := make(map[string]bool)
+		for _, ref := range req.Schemes {
+			name := schemeHeaderName(ref.Scheme)
+			if name == "" || inReq[strings.ToLower(name)] {
+				continue
+			}
+			key := strings.ToLower(name)
+			inReq[key] = true
+			count[key]++
+			if _, ok := index[key]; ok {
+				continue
+			}
+			desc := ref.Scheme.Description
+			if desc == "" {
+				desc = schemeTypeLabel(*ref.Scheme, loc)
+			}
+			index[key] = len(res)
+			res = append(res, ParamInfo{Name: name, In: "header", Type: "string", Desc: desc})
+		}
+	}
+	for key, i := range index {
+		res[i].Required = count[key] == len(reqs)
+	}
+	return res
+}
+
+// mergeHeaderParams 将 extra 追加到 headers，已存在的同名 Header（忽略大小写）保持不变。
+func mergeHeaderParams(headers []ParamInfo, extra []ParamInfo) []ParamInfo {
+	exists := make(map[string]struct{}, len(headers))
+	for _, h := range headers {
+		exists[strings.ToLower(h.Name)] = struct{}{}
+	}
+	for _, h := range extra {
+		if _, ok := exists[strings.ToLower(h.Name)]; ok {
+			continue
+		}
+		exists[strings.ToLower(h.Name)] = struct{}{}
+		headers = append(headers, h)
+	}
+	return headers
+}
+
+// schemeTypeLabel 认证方式的类型说明，如 “HTTP Bearer (JWT)”、“API Key (header: X-API-Key)”。
+func schemeTypeLabel(s SecuritySchemeInfo, loc Locale) string {
+	switch s.Type {
+	case "apiKey":
+		return "API Key (" + s.In + ": " + s.ParamName + ")"
+	case "http":
+		t := "HTTP " + httpSchemeName(s.Scheme)
+		if s.BearerFormat != "" {
+			t += " (" + s.BearerFormat + ")"
+		}
+		return t
+	case "oauth2":
+		return "OAuth 2.0"
+	case "openIdConnect":
+		return "OpenID Connect"
+	case "mutualTLS":
+		return "Mutual TLS"
+	}
+	if s.Type == "" {
+		return loc.T("auth_unknown")
+	}
+	return s.Type
+}
+
+// httpSchemeName http 认证方案的展示名：bearer → Bearer，basic → Basic，其余原样。
+func httpSchemeName(scheme string) string {
+	switch scheme {
+	case "bearer":
+		return "Bearer"
+	case "basic":
+		return "Basic"
+	case "digest":
+		return "Digest"
+	}
+	return scheme
+}
+
+// schemeDetails 认证方式的详细信息（每条为“名称: 值”），不含 oauth2 的权限范围。
+func schemeDetails(s SecuritySchemeInfo, loc Locale) []string {
+	var res []string
+	if s.OpenIDConnectURL != "" {
+		res = append(res, "openIdConnectUrl: "+s.OpenIDConnectURL)
+	}
+	for _, f := range s.Flows {
+		line := loc.T("auth_flow") + " " + f.Type
+		var urls []string
+		if f.AuthorizationURL != "" {
+			urls = append(urls, "authorizationUrl: "+f.AuthorizationURL)
+		}
+		if f.TokenURL != "" {
+			urls = append(urls, "tokenUrl: "+f.TokenURL)
+		}
+		if f.RefreshURL != "" {
+			urls = append(urls, "refreshUrl: "+f.RefreshURL)
+		}
+		if len(urls) > 0 {
+			line += " (" + strings.Join(urls, ", ") + ")"
+		}
+		res = append(res, line)
+	}
+	return res
+}
+
+// flowScopes 合并各授权流程的权限范围（按名称去重，保持首次出现的说明）。
+func flowScopes(s SecuritySchemeInfo) []ScopeInfo {
+	var res []ScopeInfo
+	seen := make(map[string]bool)
+	for _, f := range s.Flows {
+		for _, sc := range f.Scopes {
+			if !seen[sc.Name] {
+				seen[sc.Name] = true
+				res = append(res, sc)
+			}
+		}
+	}
+	return res
+}
+
+// requirementText 渲染单个认证要求：各认证方式以 “+” 连接，oauth2 附所需权限范围；空要求为“可匿名访问”。
+// html 为 true 时输出 HTML（转义文本、<code> 包裹名称），否则输出 Markdown。
+func requirementText(req SecurityRequirement, loc Locale, html bool) string {
+	esc := func(s string) string { return s }
+	code := func(s string) string { return "`" + s + "`" }
+	if html {
+		esc = htmlEscape
+		code = func(s string) string { return "<code>" + htmlEscape(s) + "</code>" }
+	}
+	if len(req.Schemes) == 0 {
+		return esc(loc.T("auth_optional"))
+	}
+	parts := make([]string, 0, len(req.Schemes))
+	for _, ref := range req.Schemes {
+		t := code(ref.Name)
+		if ref.Scheme != nil {
+			t += " " + esc(schemeTypeLabel(*ref.Scheme, loc))
+		} else {
+			t += " " + esc(loc.T("auth_undeclared"))
+		}
+		if len(ref.Scopes) > 0 {
+			scopes := make([]string, len(ref.Scopes))
+			for i, s := range ref.Scopes {
+				scopes[i] = code(s)
+			}
+			t += " · " + esc(loc.T("auth_scopes")) + ": " + strings.Join(scopes, ", ")
+		}
+		parts = append(parts, t)
+	}
+	return strings.Join(parts, " + ")
+}
+
+// renderSecurityHTML 接口认证要求的 HTML 片段；未声明时返回空串，security: [] 时为“无需认证”。
+func renderSecurityHTML(reqs []SecurityRequirement, declared bool, loc Locale) string {
+	if !declared {
+		return ""
+	}
+	if len(reqs) == 0 {
+		return "<p class=\"security-public\">" + htmlEscape(loc.T("auth_public")) + "</p>"
+	}
+	var b strings.Builder
+	if len(reqs) > 1 {
+		b.WriteString("<p class=\"security-note\">" + htmlEscape(loc.T("auth_any_of")) + "</p>")
+	}
+	b.WriteString("<ul class=\"security\">")
+	for _, req := range reqs {
+		b.WriteString("<li>" + requirementText(req, loc, true) + "</li>")
+	}
+	b.WriteString("</ul>")
+	return b.String()
+}
+
+// renderSecurityMarkdown 接口认证要求的 Markdown 片段。
+func renderSecurityMarkdown(reqs []SecurityRequirement, declared bool, loc Locale) string {
+	if !declared {
+		return ""
+	}
+	if len(reqs) == 0 {
+		return loc.T("auth_public") + "\n\n"
+	}
+	var b strings.Builder
+	if len(reqs) > 1 {
+		b.WriteString(loc.T("auth_any_of") + "\n\n")
+	}
+	for _, req := range reqs {
+		b.WriteString("- " + requirementText(req, loc, false) + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderSecuritySchemesHTML 文档级“认证方式”区块：每个认证方式的类型、说明、流程与权限范围。
+func renderSecuritySchemesHTML(schemes []SecuritySchemeInfo, loc Locale) string {
+	if len(schemes) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, s := range schemes {
+		b.WriteString("<div class=\"security-scheme\"><h3 id=\"auth-" + htmlEscape(slugify(s.Name)) + "\"><code>" + htmlEscape(s.Name) + "</code> " + htmlEscape(schemeTypeLabel(s, loc)) + "</h3>")
+		if s.Description != "" {
+			b.WriteString("<div class=\"desc\">" + string(markdownHTML(s.Description)) + "</div>")
+		}
+		if details := schemeDetails(s, loc); len(details) > 0 {
+			b.WriteString("<ul>")
+			for _, d := range details {
+				b.WriteString("<li>" + htmlEscape(d) + "</li>")
+			}
+			b.WriteString("</ul>")
+		}
+		if scopes := flowScopes(s); len(scopes) > 0 {
+			b.WriteString("<table><thead><tr><th>" + htmlEscape(loc.T("auth_scopes")) + "</th><th>" + htmlEscape(loc.T("col_desc")) + "</th></tr></thead><tbody>")
+			for _, sc := range scopes {
+				b.WriteString("<tr><td><code>" + htmlEscape(sc.Name) + "</code></td><td>" + htmlEscape(sc.Desc) + "</td></tr>")
+			}
+			b.WriteString("</tbody></table>")
+		}
+		b.WriteString("</div>")
+	}
+	return b.String()
+}
+
+// renderSecuritySchemesMarkdown 以 Markdown 渲染文档级“认证方式”区块（二级标题起）。
+func renderSecuritySchemesMarkdown(schemes []SecuritySchemeInfo, loc Locale) string {
+	if len(schemes) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("## " + loc.T("auth_title") + "\n\n")
+	for _, s := range schemes {
+		b.WriteString("### `" + s.Name + "` " + schemeTypeLabel(s, loc) + "\n\n")
+		if s.Description != "" {
+			b.WriteString(s.Description + "\n\n")
+		}
+		if details := schemeDetails(s, loc); len(details) > 0 {
+			for _, d := range details {
+				b.WriteString("- " + d + "\n")
+			}
+			b.WriteString("\n")
+		}
+		if scopes := flowScopes(s); len(scopes) > 0 {
+			b.WriteString(tableHeadMarkdown(loc.T("auth_scopes"), loc.T("col_desc")))
+			for _, sc := range scopes {
+				b.WriteString("| `" + sc.Name + "` | " + strings.ReplaceAll(sc.Desc, "|", "\\|") + " |\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// securitySpec 文档级要求 bearer；/keys 可用 apiKey 或 bearer+oauth2；/public 为 security: []；/legacy 引用未声明的认证方式。
+const securitySpec = `{"security":[{"bearer":[]}],
+	"paths":{
+		"/me":{"get":{"responses":{"200":{"description":"ok"}}}},
+		"/keys":{"get":{"security":[{"apiKey":[]},{"bearer":[],"oauth":["read","write"]}],
+			"parameters":[{"name":"authorization","in":"header","description":"自定义说明","schema":{"type":"string"}}],
+			"responses":{"200":{"description":"ok"}}}},
+		"/public":{"get":{"security":[],"responses":{"200":{"description":"ok"}}}},
+		"/legacy":{"get":{"security":[{"ghost":[]},{}],"responses":{"200":{"description":"ok"}}}}},
+	"components":{"securitySchemes":{
+		"bearer":{"type":"http","scheme":"Bearer","bearerFormat":"JWT"},
+		"apiKey":{"$ref":"#/components/securitySchemes/key"},
+		"key":{"type":"apiKey","in":"header","name":"X-API-Key","description":"调用方密钥"},
+		"oauth":{"type":"oauth2","flows":{"clientCredentials":{"tokenUrl":"https://auth.example.com/token","scopes":{"write":"写","read":"读"}}}}}}}`
+
+func securityEndpoint(t *testing.T, path string, cfg RenderConfig) EndpointData {
+	t.Helper()
+	j := gjson.New(securitySpec)
+	pj := j.GetJsonMap("paths")[path]
+	return buildEndpoint(j, path, "get", pj, pj.GetJson("get"), cfg)
+}
+
+func TestSecuritySchemes(t *testing.T) {
+	schemes := securitySchemes(gjson.New(securitySpec))
+	var names []string
+	for _, s := range schemes {
+		names = append(names, s.Name)
+	}
+	if got := strings.Join(names, ","); got != "apiKey,bearer,key,oauth" {
+		t.Fatalf("scheme names = %s", got)
+	}
+	loc := RenderConfig{Lang: LangEn}.locale()
+	labels := map[string]string{
+		"apiKey": "API Key (header: X-API-Key)",
+		"bearer": "HTTP Bearer (JWT)",
+		"oauth":  "OAuth 2.0",
+	}
+	for _, s := range schemes {
+		if want, ok := labels[s.Name]; ok && schemeTypeLabel(s, loc) != want {
+			t.Errorf("label of %s = %q, want %q", s.Name, schemeTypeLabel(s, loc), want)
+		}
+	}
+	oauth := schemes[3]
+	if len(oauth.Flows) != 1 || oauth.Flows[0].TokenURL != "https://auth.example.com/token" ||
+		len(oauth.Flows[0].Scopes) != 2 || oauth.Flows[0].Scopes[0].Name != "read" {
+		t.Errorf("oauth flows = %+v", oauth.Flows)
+	}
+	if got := schemeTypeLabel(SecuritySchemeInfo{}, loc); got != "unknown type" {
+		t.Errorf("empty type label = %q", got)
+	}
+}
+
+func TestEndpointSecurity(t *testing.T) {
+	cfg := RenderConfig{Lang: LangEn}
+	cases := []struct {
+		path     string
+		declared bool
+		reqs     string
+		headers  string
+	}{
+		// 继承文档级 security
+		{"/me", true, "bearer", "Authorization*"},
+		// 接口级优先；多项之间任选其一，仅出现在部分项中的 Header 不是必选；已声明的同名 Header 保持不变
+		{"/keys", true, "apiKey|bearer+oauth", "authorization,X-API-Key"},
+		{"/public", true, "", ""},
+		// 未声明的认证方式与匿名项不产生 Header
+		{"/legacy", true, "ghost|", ""},
+	}
+	for _, c := range cases {
+		ep := securityEndpoint(t, c.path, cfg)
+		var reqs, headers []string
+		for _, r := range ep.Security {
+			var names []string
+			for _, s := range r.Schemes {
+				names = append(names, s.Name)
+			}
+			reqs = append(reqs, strings.Join(names, "+"))
+		}
+		for _, h := range ep.Headers {
+			name := h.Name
+			if h.Required {
+				name += "*"
+			}
+			headers = append(headers, name)
+		}
+		if ep.SecurityDeclared != c.declared || strings.Join(reqs, "|") != c.reqs || strings.Join(headers, ",") != c.headers {
+			t.Errorf("%s: declared=%v reqs=%q headers=%q, want %v %q %q", c.path, ep.SecurityDeclared,
+				strings.Join(reqs, "|"), strings.Join(headers, ","), c.declared, c.reqs, c.headers)
+		}
+	}
+
+	keys := securityEndpoint(t, "/keys", cfg)
+	if keys.Headers[0].Desc != "自定义说明" || keys.Headers[1].Desc != "调用方密钥" {
+		t.Errorf("header descriptions = %+v", keys.Headers)
+	}
+	if keys.Security[1].Schemes[1].Scopes[0] != "read" || keys.Security[1].Schemes[0].Scheme == nil {
+		t.Errorf("requirement = %+v", keys.Security[1])
+	}
+	if html := string(keys.SecurityHTML); !strings.Contains(html, "Any one of the following:") ||
+		!strings.Contains(html, "<code>oauth</code> OAuth 2.0 · Scopes: <code>read</code>, <code>write</code>") {
+		t.Errorf("security html = %s", html)
+	}
+
+	if html := string(securityEndpoint(t, "/public", cfg).SecurityHTML); !strings.Contains(html, "No authentication required (public)") {
+		t.Errorf("public security html = %s", html)
+	}
+	legacy := securityEndpoint(t, "/legacy", cfg)
+	if md := renderSecurityMarkdown(legacy.Security, legacy.SecurityDeclared, legacy.Locale); !strings.Contains(md, "- `ghost` (undeclared scheme)\n") ||
+		!strings.Contains(md, "- Anonymous access allowed\n") {
+		t.Errorf("legacy security markdown = %q", md)
+	}
+}
+
+func TestSecurityUndeclared(t *testing.T) {
+	j := gjson.New(`{"paths":{"/a":{"get":{"responses":{"200":{"description":"ok"}}}}}}`)
+	pj := j.GetJsonMap("paths")["/a"]
+	ep := buildEndpoint(j, "/a", "get", pj, pj.GetJson("get"), RenderConfig{})
+	if ep.SecurityDeclared || len(ep.Security) != 0 || ep.SecurityHTML != "" || len(ep.Headers) != 0 {
+		t.Errorf("undeclared security = %+v", ep)
+	}
+}
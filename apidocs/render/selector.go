@@ -0,0 +1,213 @@
+package render
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// selectorKind 规则选择器类型，取值越大优先级越高。
+type selectorKind int
+
+const (
+	selectorTag   selectorKind = iota + 1 // tag:<标签名>
+	selectorRegex                         // re:<正则>
+	selectorGlob                          // 含 * 或 ** 的路径
+	selectorPath                          // 精确路径（允许路径模板）
+	selectorOpID                          // operationId:<id>
+)
+
+// ruleSelector Customize 规则键解析后的选择器。
+// 键的写法：[方法[,方法...] ]<选择器>，选择器为以下之一：
+// - /users/{id}：精确路径；{...} 匹配单个路径段，与规范中的参数名无关（/users/{id} 命中 /users/{userId}）
+// - /users/*、/admin/**：锚定的通配，* 匹配段内任意字符（不跨 /），** 匹配任意层级（含零层）
+// - re:^/v[12]/users：正则，隐式锚定为整条路径匹配
+// - tag:用户管理/列表：接口的 tags 含该标签（完全相等）
+// - operationId:getUser：接口的 operationId 相等
+type ruleSelector struct {
+	Key     string
+	Methods []string
+	Kind    selectorKind
+	Value   string
+	re      *regexp.Regexp
+	literal int
+}
+
+// selectorCache 规则键的解析结果，按键缓存；规则整体替换（热更新）时由 ResetSelectorCache 清空，旧规则键不会常驻内存。
+var selectorCache sync.Map
+
+// ResetSelectorCache 清空规则键的解析缓存。定制规则整体替换（如规则文件热更新）后调用，释放不再使用的规则键，
+// 之后用到的规则键按需重新解析。
+func ResetSelectorCache() {
+	selectorCache.Clear()
+}
+
+// ValidateSelector 校验 Customize 规则键，返回解析错误；合法时返回 nil。
+// 键的写法：[方法[,方法...] ]<选择器>，选择器为精确路径、路径通配（* 与 **）、re:<正则>、tag:<标签名> 或 operationId:<id>。
+func ValidateSelector(key string) error {
+	_, err := parseSelector(key)
+	return err
+}
+
+// parseSelector 解析规则键，结果按键缓存。
+func parseSelector(key string) (*ruleSelector, error) {
+	if v, ok := selectorCache.Load(key); ok {
+		return v.(*ruleSelector), nil
+	}
+	s := &ruleSelector{Key: key}
+	rest := strings.TrimSpace(key)
+	if i := strings.IndexAny(rest, " \t"); i > 0 {
+		if methods, ok := parseMethodList(rest[:i]); ok {
+			s.Methods = methods
+			rest = strings.TrimSpace(rest[i:])
+		}
+	}
+	lower := strings.ToLower(rest)
+	switch {
+	case rest == "":
+		return nil, errors.New("empty selector")
+	case strings.HasPrefix(lower, "tag:"):
+		s.Kind, s.Value = selectorTag, strings.TrimSpace(rest[len("tag:"):])
+	case strings.HasPrefix(lower, "operationid:"):
+		s.Kind, s.Value = selectorOpID, strings.TrimSpace(rest[len("operationid:"):])
+	case strings.HasPrefix(lower, "re:"):
+		s.Kind, s.Value = selectorRegex, rest[len("re:"):]
+		re, err := regexp.Compile("^(?:" + s.Value + ")$")
+		if err != nil {
+			return nil, errors.New("invalid regex: " + err.Error())
+		}
+		s.re = re
+	case strings.HasPrefix(rest, "/"):
+		s.Kind, s.Value = selectorPath, rest
+		if strings.Contains(rest, "*") {
+			s.Kind = selectorGlob
+		}
+		s.re = globRegexp(rest)
+		s.literal = globLiteralLen(rest)
+	default:
+		return nil, errors.New("selector must be a path starting with /, re:<regex>, tag:<name> or operationId:<id>")
+	}
+	if s.Value == "" {
+		return nil, errors.New("empty selector value")
+	}
+	selectorCache.Store(key, s)
+	return s, nil
+}
+
+// parseMethodList 解析以逗号分隔的 HTTP 方法列表（不区分大小写）；含非方法项时返回 false。
+func parseMethodList(s string) ([]string, bool) {
+	var res []string
+	for _, m := range strings.Split(s, ",") {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if !isHTTPMethod(m) {
+			return nil, false
+		}
+		res = append(res, m)
+	}
+	return res, len(res) > 0
+}
+
+// globRegexp 将路径通配编译为锚定正则：** 段匹配任意层级，段内 * 匹配非 / 字符，{...} 匹配非空段内容。
+// 路径首尾的 / 不参与匹配。
+func globRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	if p := strings.Trim(pattern, "/"); p != "" {
+		for _, seg := range strings.Split(p, "/") {
+			if seg == "**" {
+				b.WriteString("(?:/[^/]+)*")
+				continue
+			}
+			b.WriteString("/")
+			for i := 0; i < len(seg); i++ {
+				switch c := seg[i]; {
+				case c == '*':
+					b.WriteString("[^/]*")
+				case c == '{' && strings.IndexByte(seg[i:], '}') > 0:
+					b.WriteString("[^/]+")
+					i += strings.IndexByte(seg[i:], '}')
+				default:
+					b.WriteString(regexp.QuoteMeta(string(c)))
+				}
+			}
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// globLiteralLen 通配中字面量字符的数量，用于同类选择器的优先级比较（越长越具体）。
+func globLiteralLen(pattern string) int {
+	n := 0
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '*':
+		case c == '{' && strings.IndexByte(pattern[i:], '}') > 0:
+			i += strings.IndexByte(pattern[i:], '}')
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// matches 判断选择器是否命中接口。
+func (s *ruleSelector) matches(path, method string, op *gjson.Json) bool {
+	if len(s.Methods) > 0 {
+		hit := false
+		for _, m := range s.Methods {
+			hit = hit || m == strings.ToLower(method)
+		}
+		if !hit {
+			return false
+		}
+	}
+	switch s.Kind {
+	case selectorTag:
+		if op == nil {
+			return false
+		}
+		for _, t := range jsonArrayStrings(op.Get("tags").Array()) {
+			if t == s.Value {
+				return true
+			}
+		}
+		return false
+	case selectorOpID:
+		return op != nil && op.Get("operationId").String() == s.Value
+	}
+	return s.re.MatchString(strings.TrimRight(path, "/"))
+}
+
+// higherThan 选择器优先级：operationId > 精确路径 > 通配 > 正则 > 标签；
+// 同类时限定方法者优先，其次字面量更长者优先，最后按键的字典序，保证结果确定。
+func (s *ruleSelector) higherThan(o *ruleSelector) bool {
+	if s.Kind != o.Kind {
+		return s.Kind > o.Kind
+	}
+	if (len(s.Methods) > 0) != (len(o.Methods) > 0) {
+		return len(s.Methods) > 0
+	}
+	if s.literal != o.literal {
+		return s.literal > o.literal
+	}
+	return s.Key < o.Key
+}
+
+// matchingSelectors 返回命中接口的规则键，按优先级从高到低排列；无法解析的键被忽略。
+func matchingSelectors(rules map[string]CustomizeReqAndRes, path, method string, op *gjson.Json) []*ruleSelector {
+	var res []*ruleSelector
+	for key := range rules {
+		s, err := parseSelector(key)
+		if err != nil || !s.matches(path, method, op) {
+			continue
+		}
+		res = append(res, s)
+	}
+	sort.Slice(res, func(a, b int) bool { return res[a].higherThan(res[b]) })
+	return res
+}
@@ -1,116 +1,156 @@
 package render
 
 import (
+	"fmt"
 	"html/template"
+	"io/fs"
 	"os"
-	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 
 	tpl "github.com/megatrZlp/go-apidocs/apidocs/templates"
 )
 
-func loadTemplateContent(dir string, name string) (string, error) {
-	// 优先读取用户配置的模板目录（绝对或相对路径均可）
-	if dir != "" {
-		p := filepath.Join(dir, name)
-		if b, err := os.ReadFile(p); err == nil {
-			return string(b), nil
+// templateFiles 组成页面的模板文件，按此顺序解析；style/script/layout 为必需文件，其余缺失时忽略。
+var templateFiles = []string{
+	"style.tmpl",
+	"script.tmpl",
+	"layout.tmpl",
+	"endpoint.tmpl",
+	"nav.tmpl",
+	"headings.tmpl",
+	"main_header.tmpl",
+	"group_heading.tmpl",
+	"sub_heading.tmpl",
+	"tag_group_heading.tmpl",
+}
+
+// requiredTemplateFiles 必需的模板文件（内置模板总能提供）。
+var requiredTemplateFiles = map[string]bool{"style.tmpl": true, "script.tmpl": true, "layout.tmpl": true}
+
+// templateSources 按优先级返回模板来源：
+// 1) RenderConfig.TemplateFS（按切片顺序，靠前者优先）
+// 2) RenderConfig.TemplateDir（os.DirFS）
+// 3) 内置模板
+// 每个文件独立查找，来源中缺失的文件回退到下一个来源；不探测当前工作目录。
+func templateSources(cfg RenderConfig) []fs.FS {
+	srcs := make([]fs.FS, 0, len(cfg.TemplateFS)+2)
+	for _, f := range cfg.TemplateFS {
+		if f != nil {
+			srcs = append(srcs, f)
 		}
 	}
-	// 回退顺序：
-	// 1) 项目中的 apidocs/templates
-	// 2) 当前工作目录下的 templates（便于快速试验）
-	fallbacks := []string{
-		filepath.Join("apidocs", "templates", name),
-		filepath.Join("templates", name),
+	if cfg.TemplateDir != "" {
+		srcs = append(srcs, os.DirFS(cfg.TemplateDir))
 	}
-	for _, p := range fallbacks {
-		if b, err := os.ReadFile(p); err == nil {
+	return append(srcs, tpl.Embedded)
+}
+
+// loadTemplateContent 按来源优先级读取单个模板文件。
+func loadTemplateContent(srcs []fs.FS, name string) (string, error) {
+	for _, f := range srcs {
+		if b, err := fs.ReadFile(f, name); err == nil {
 			return string(b), nil
 		}
 	}
-	if s, err := tpl.Read(name); err == nil {
-		return s, nil
-	}
 	return "", os.ErrNotExist
 }
 
-func buildLayoutTemplate(dir string) (*template.Template, error) {
-	// 依次加载样式、脚本与布局模板，后续动态片段可选加载
-	style, err := loadTemplateContent(dir, "style.tmpl")
-	if err != nil {
-		return nil, err
-	}
-	script, err := loadTemplateContent(dir, "script.tmpl")
-	if err != nil {
-		return nil, err
-	}
-	layout, err := loadTemplateContent(dir, "layout.tmpl")
-	if err != nil {
-		return nil, err
+// cachedTemplate 缓存条目：模板来源、自定义函数与解析结果。
+type cachedTemplate struct {
+	srcs  []fs.FS
+	funcs string
+	t     *template.Template
+}
+
+// templateCache 已解析模板的进程级缓存；条目数等于不同的模板配置数，线性查找即可。
+var templateCache struct {
+	sync.Mutex
+	entries []cachedTemplate
+}
+
+// funcsKey 返回自定义函数的缓存键：函数名与函数值（代码指针）按名称排序后拼接。
+// 同名但实现不同的函数得到不同的键；由同一函数字面量创建、仅捕获变量不同的闭包共用同一个键。
+func funcsKey(fm template.FuncMap) string {
+	names := make([]string, 0, len(fm))
+	for k := range fm {
+		names = append(names, k)
 	}
-	// 端点模板非必须，允许为空以便仅输出最简布局
-	endpoint, err := loadTemplateContent(dir, "endpoint.tmpl")
-	if err != nil {
-		endpoint = ""
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, k := range names {
+		var ptr uintptr
+		if v := reflect.ValueOf(fm[k]); v.Kind() == reflect.Func {
+			ptr = v.Pointer()
+		}
+		parts = append(parts, fmt.Sprintf("%s=%x", k, ptr))
 	}
-	nav, err := loadTemplateContent(dir, "nav.tmpl")
-	if err != nil {
-		nav = ""
+	return strings.Join(parts, ",")
+}
+
+// sameSources 判断两组模板来源是否相同；不可比较的来源（如 fstest.MapFS）视为不同，即不缓存。
+func sameSources(a, b []fs.FS) bool {
+	if len(a) != len(b) {
+		return false
 	}
-	headings, err := loadTemplateContent(dir, "headings.tmpl")
-	if err != nil {
-		headings = ""
+	for i := range a {
+		ta, tb := reflect.TypeOf(a[i]), reflect.TypeOf(b[i])
+		if ta != tb || !ta.Comparable() || a[i] != b[i] {
+			return false
+		}
 	}
-	groupHeading, err := loadTemplateContent(dir, "group_heading.tmpl")
-	if err != nil {
-		groupHeading = ""
+	return true
+}
+
+// buildLayoutTemplate 返回合并了全部模板片段与模板函数的模板实例。
+// 非 DevMode 下按（模板来源, 自定义函数）缓存解析结果，同一配置只解析一次；缓存中的模板从不执行，每次返回其副本，
+// 调用方可在副本上重新绑定页面相关的函数（如 anchor）；
+// DevMode 下每次重新读取与解析，便于修改模板后直接刷新页面。
+func buildLayoutTemplate(cfg RenderConfig) (*template.Template, error) {
+	srcs := templateSources(cfg)
+	if cfg.DevMode {
+		return parseLayoutTemplate(srcs, cfg)
 	}
-	subHeading, err := loadTemplateContent(dir, "sub_heading.tmpl")
-	if err != nil {
-		subHeading = ""
+	funcs := funcsKey(cfg.Funcs)
+	templateCache.Lock()
+	defer templateCache.Unlock()
+	for _, e := range templateCache.entries {
+		if e.funcs == funcs && sameSources(e.srcs, srcs) {
+			return e.t.Clone()
+		}
 	}
-	mainHeader, err := loadTemplateContent(dir, "main_header.tmpl")
+	t, err := parseLayoutTemplate(srcs, cfg)
 	if err != nil {
-		mainHeader = ""
-	}
-	// 合并所有模板片段到同一个模板实例中
-	t := template.New("layout")
-	if _, err = t.Parse(style); err != nil {
-		return nil, err
-	}
-	if _, err = t.Parse(script); err != nil {
-		return nil, err
-	}
-	if _, err = t.Parse(layout); err != nil {
 		return nil, err
 	}
-	if endpoint != "" {
-		if _, err = t.Parse(endpoint); err != nil {
-			return nil, err
+	cacheable := true
+	for _, f := range srcs {
+		if !reflect.TypeOf(f).Comparable() {
+			cacheable = false
 		}
 	}
-	if nav != "" {
-		if _, err = t.Parse(nav); err != nil {
-			return nil, err
-		}
+	if !cacheable {
+		return t, nil
 	}
-	if headings != "" {
-		if _, err = t.Parse(headings); err != nil {
-			return nil, err
-		}
-	}
-	if mainHeader != "" {
-		if _, err = t.Parse(mainHeader); err != nil {
-			return nil, err
-		}
-	}
-	if groupHeading != "" {
-		if _, err = t.Parse(groupHeading); err != nil {
-			return nil, err
+	templateCache.entries = append(templateCache.entries, cachedTemplate{srcs: srcs, funcs: funcs, t: t})
+	return t.Clone()
+}
+
+// parseLayoutTemplate 依次读取并解析所有模板片段到同一个模板实例中。
+func parseLayoutTemplate(srcs []fs.FS, cfg RenderConfig) (*template.Template, error) {
+	t := template.New("layout").Funcs(templateFuncs(cfg))
+	for _, name := range templateFiles {
+		content, err := loadTemplateContent(srcs, name)
+		if err != nil {
+			if requiredTemplateFiles[name] {
+				return nil, err
+			}
+			// 可选片段缺失时跳过，允许仅输出最简布局
+			continue
 		}
-	}
-	if subHeading != "" {
-		if _, err = t.Parse(subHeading); err != nil {
+		if _, err = t.Parse(content); err != nil {
 			return nil, err
 		}
 	}
@@ -118,29 +158,80 @@ func buildLayoutTemplate(dir string) (*template.Template, error) {
 }
 
 type pageData struct {
+	Locale   Locale
 	Title    string
 	NavHTML  template.HTML
 	MainHTML template.HTML
+	// AnchorRedirects 旧锚点到新锚点的跳转表（JSON 对象），script 模板在找不到 hash 对应元素时使用
+	AnchorRedirects template.JS
 }
 
+// EndpointData 接口区块的视图模型（endpoint 模板的数据）。
+// 类型化字段（参数、字段、返回与示例）供自定义模板自行渲染；
+// *HTML 字段与 ReqExample/ResExample 为内置模板使用的预渲染便捷片段。
 type EndpointData struct {
-	Anchor          string
-	MethodUpper     string
-	Summary         string
-	Path            string
-	ContentType     string
-	HeadersHTML     template.HTML
-	PathParamsHTML  template.HTML
-	QueryParamsHTML template.HTML
-	ReqExample      template.HTML
-	ReqTableHTML    template.HTML
-	ResExample      template.HTML
-	ResTableHTML    template.HTML
+	// Locale 当前语言的文案目录，模板中使用 {{.Locale.T "request_url"}}
+	Locale      Locale
+	Anchor      string
+	Method      string
+	MethodUpper string
+	Summary     string
+	Description string
+	OperationID string
+	Tags        []string
+	Path        string
+	// Deprecation 接口的废弃标记（Deprecated、DeprecatedSince、Sunset）；DeprecatedText 为其展示文本
+	Deprecation
+	DeprecatedText string
+	// URLs 各服务器下的完整地址（接口级 servers 优先，其次为路径级与文档级）；未声明 servers 时为空
+	URLs        []EndpointURL
+	ContentType string
+	// Note/Warning 定制规则附加的说明与警告提示（Markdown），模板中使用 {{markdown .Note}}
+	Note    string
+	Warning string
+
+	// Security 生效的认证要求（多项之间任选其一）；SecurityDeclared 为 false 表示接口与文档均未声明 security，
+	// 为 true 且 Security 为空表示公开接口（security: []）
+	Security         []SecurityRequirement
+	SecurityDeclared bool
+
+	Headers      []ParamInfo
+	PathParams   []ParamInfo
+	QueryParams  []ParamInfo
+	CookieParams []ParamInfo
+
+	// HasRequestBody 为 true 时 RequestFields/RequestExample 有效
+	HasRequestBody bool
+	RequestFields  []FieldInfo
+	// RequestVariants 请求体中 oneOf/anyOf 的变体（各自的字段与示例）
+	RequestVariants []VariantGroup
+	RequestExample  string
+	RequestExamples []ExampleInfo
+
+	// 主返回（优先 200）的状态码、媒体类型、字段与示例
+	ResponseStatus      string
+	ResponseContentType string
+	ResponseFields      []FieldInfo
+	ResponseVariants    []VariantGroup
+	ResponseExample     string
+	// Responses 按状态码排序的全部返回
+	Responses []ResponseInfo
+
+	SecurityHTML     template.HTML
+	HeadersHTML      template.HTML
+	PathParamsHTML   template.HTML
+	QueryParamsHTML  template.HTML
+	CookieParamsHTML template.HTML
+	ReqExample       template.HTML
+	ReqTableHTML     template.HTML
+	ResExample       template.HTML
+	ResTableHTML     template.HTML
 }
 
 type NavItemVM struct {
-	Summary string
-	Anchor  string
+	Summary    string
+	Anchor     string
+	Deprecated bool
 }
 
 type NavGroupVM struct {
@@ -151,6 +242,16 @@ type NavGroupVM struct {
 }
 
 type NavData struct {
+	Locale Locale
+	Groups []*NavGroupVM
+	// TagGroups 按 x-tagGroups 归类的顶层分组（与 Groups 为同一批分组）；文档未设置 x-tagGroups 时为空
+	TagGroups []*NavTagGroupVM
+}
+
+// NavTagGroupVM x-tagGroups 中的一项及其包含的顶层分组。
+type NavTagGroupVM struct {
+	Name   string
+	Id     string
 	Groups []*NavGroupVM
 }
 
@@ -165,16 +266,34 @@ type HeadingsData struct {
 }
 
 type MainHeaderData struct {
+	Locale  Locale
 	Title   string
 	MdRoute string
+	// Info 文档头部信息（版本、说明、联系人、许可、外部文档与 servers）
+	Info DocInfo
+	// SecuritySchemes components.securitySchemes；SecurityHTML 为内置模板使用的“认证方式”区块
+	SecuritySchemes []SecuritySchemeInfo
+	SecurityHTML    template.HTML
 }
 
+// GroupHeadingData 一级分组标题；Name 为展示名称（x-displayName 优先），Tag 为文档级 tags 中声明的说明（未声明时仅含名称）。
 type GroupHeadingData struct {
-	Id   string
-	Name string
+	Locale Locale
+	Id     string
+	Name   string
+	Tag    TagInfo
 }
 
+// SubHeadingData 次级分组标题，字段同 GroupHeadingData。
 type SubHeadingData struct {
+	Locale Locale
+	Id     string
+	Name   string
+	Tag    TagInfo
+}
+
+// TagGroupHeadingData x-tagGroups 分组标题。
+type TagGroupHeadingData struct {
 	Id   string
 	Name string
 }
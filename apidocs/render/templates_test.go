@@ -0,0 +1,56 @@
+package render
+
+import (
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// writeTemplateDir 在临时目录写入模板文件并返回 os.DirFS（可比较，参与缓存）。
+func writeTemplateDir(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestTemplateCacheDistinguishesFuncValues(t *testing.T) {
+	dir := writeTemplateDir(t, map[string]string{"layout.tmpl": `{{define "layout"}}[{{fmt .Title}}]{{end}}`})
+	j := gjson.New(`{"info":{"title":"Docs"},"paths":{}}`)
+	render := func(f func(string) string) string {
+		cfg := RenderConfig{TemplateFS: []fs.FS{os.DirFS(dir)}, Funcs: template.FuncMap{"fmt": f}}
+		return GenerateHTMLWithConfig(j, "", cfg)
+	}
+	if got := render(strings.ToUpper); got != "[DOCS]" {
+		t.Errorf("first config = %q, want [DOCS]", got)
+	}
+	if got := render(strings.ToLower); got != "[docs]" {
+		t.Errorf("second config = %q, want [docs] (cached template must not reuse the first func)", got)
+	}
+	if got := render(strings.ToUpper); got != "[DOCS]" {
+		t.Errorf("first config again = %q, want [DOCS]", got)
+	}
+}
+
+func TestAnchorFuncMatchesPageAnchors(t *testing.T) {
+	dir := writeTemplateDir(t, map[string]string{"endpoint.tmpl": `{{define "endpoint"}}<div id="{{.Anchor}}" data-func="{{anchor .Method .Path}}"></div>{{end}}`})
+	spec := `{"paths":{"/a/{id}":{"get":{}},"/a/id":{"get":{"operationId":"listA"}}}}`
+	j := gjson.New(spec)
+	for _, cfg := range []RenderConfig{{}, {AnchorOperationID: true}} {
+		cfg.TemplateFS = []fs.FS{os.DirFS(dir)}
+		page := GenerateHTMLWithConfig(j, spec, cfg)
+		for _, id := range []string{"get-a-id", map[bool]string{false: "get-a-id-2", true: "listA"}[cfg.AnchorOperationID]} {
+			if !strings.Contains(page, `<div id="`+id+`" data-func="`+id+`">`) {
+				t.Errorf("AnchorOperationID=%v: anchor func differs from endpoint id %q", cfg.AnchorOperationID, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// VariantGroup schema 中一处 oneOf/anyOf 多态：出现位置、区分字段与各个可选结构。
+// - Path: 出现位置的字段路径（根节点为空串），变体字段的 Path 均以此为前缀
+// - Kind: oneOf 或 anyOf
+// - Discriminator/Mapping: discriminator.propertyName 与 discriminator.mapping（取值 → $ref）
+type VariantGroup struct {
+	Path          string
+	Kind          string
+	Discriminator string
+	Mapping       map[string]string
+	Variants      []VariantInfo
+}
+
+// VariantInfo 多态中的单个可选结构。
+// - Name: 组件名（$ref）、title 或基础类型名，均缺失时为 #序号
+// - DiscriminatorValue: 该结构对应的区分字段取值（mapping 反查，缺省为组件名）
+// - Example: 该结构的示例 JSON 文本（已填入区分字段取值）
+type VariantInfo struct {
+	Name               string
+	Ref                string
+	DiscriminatorValue string
+	Fields             []FieldInfo
+	Example            string
+
+	schema *gjson.Json
+}
+
+// compositeKind 返回 schema 的多态关键字（oneOf/anyOf），没有时为空串。
+func compositeKind(s *gjson.Json) string {
+	if s == nil {
+		return ""
+	}
+	for _, k := range []string{"oneOf", "anyOf"} {
+		if len(s.Get(k).Array()) > 0 {
+			return k
+		}
+	}
+	return ""
+}
+
+// objectRowType 对象行的类型展示：多态对象显示为 oneOf/anyOf，映射显示为 map<string, X>，其余为 object。
+func objectRowType(j *gjson.Json, s *gjson.Json) string {
+	if r := s.Get("$ref").String(); r != "" {
+		s = getRefJson(j, r)
+	}
+	if len(mergedProperties(j, s)) > 0 {
+		return "object"
+	}
+	if k := compositeKind(s); k != "" {
+		return k
+	}
+	if m := mapTypeName(j, s); m != "" {
+		return m
+	}
+	return "object"
+}
+
+// variantGroupOf 解析 schema 上的 oneOf/anyOf 为变体分组（不展开字段）；没有多态时返回 nil。
+func variantGroupOf(j *gjson.Json, s *gjson.Json, path string) *VariantGroup {
+	kind := compositeKind(s)
+	if kind == "" {
+		return nil
+	}
+	g := &VariantGroup{
+		Path:          path,
+		Kind:          kind,
+		Discriminator: s.Get("discriminator.propertyName").String(),
+		Mapping:       s.Get("discriminator.mapping").MapStrStr(),
+	}
+	for i, it := range s.Get(kind).Array() {
+		vj := gjson.New(it)
+		v := VariantInfo{schema: vj, Ref: vj.Get("$ref").String()}
+		body := vj
+		if v.Ref != "" {
+			v.Name = componentNameFromRef(v.Ref)
+			body = getRefJson(j, v.Ref)
+		}
+		if v.Name == "" && body != nil {
+			v.Name = body.Get("title").String()
+			if v.Name == "" && !isObjectSchema(body) {
+				v.Name = schemaTypeName(body)
+			}
+		}
+		if v.Name == "" {
+			v.Name = "#" + strconv.Itoa(i+1)
+		}
+		if g.Discriminator != "" {
+			v.DiscriminatorValue = discriminatorValue(j, g, v, body)
+		}
+		g.Variants = append(g.Variants, v)
+	}
+	return g
+}
+
+// discriminatorValue 确定变体的区分字段取值：mapping 中指向该变体的键优先；
+// 其次为变体中区分字段的首个枚举值；引用组件时缺省为组件名。
+func discriminatorValue(j *gjson.Json, g *VariantGroup, v VariantInfo, body *gjson.Json) string {
+	keys := make([]string, 0, len(g.Mapping))
+	for k := range g.Mapping {
+		keys = append(keys, k)
+	}
+	sortStrings(keys)
+	for _, k := range keys {
+		m := g.Mapping[k]
+		if (v.Ref != "" && m == v.Ref) || m == v.Name {
+			return k
+		}
+	}
+	if body != nil {
+		if pj, ok := mergedProperties(j, body)[g.Discriminator]; ok {
+			if e := pj.Get("enum").Strings(); len(e) > 0 {
+				return e[0]
+			}
+		}
+	}
+	if v.Ref != "" {
+		return v.Name
+	}
+	return ""
+}
+
+// variantFields 展开单个变体的字段（以分组位置为前缀）；基础类型变体输出一行。
+func variantFields(j *gjson.Json, g *VariantGroup, v VariantInfo, view schemaView, seen map[string]bool, groups *[]VariantGroup) []FieldInfo {
+	body := v.schema
+	if v.Ref != "" {
+		if seen[v.Ref] {
+			return nil
+		}
+		if body = getRefJson(j, v.Ref); body == nil {
+			return nil
+		}
+		seen[v.Ref] = true
+		defer delete(seen, v.Ref)
+	}
+	if !isObjectSchema(body) {
+		path := g.Path
+		if path == "" {
+			path = "(value)"
+		}
+		return []FieldInfo{{Path: path, Type: leafTypeName(j, body), Desc: titleDescription(body), Constraints: schemaConstraints(j, body)}}
+	}
+	return flattenFields(j, body, g.Path, view, seen, groups)
+}
+
+// fieldsWithVariants 合并基础字段与各变体字段，变体字段路径前加 "<变体名>"，
+// 用于变更报告与质量检查等需要覆盖全部字段的场景。
+func fieldsWithVariants(fields []FieldInfo, groups []VariantGroup) []FieldInfo {
+	out := append([]FieldInfo(nil), fields...)
+	for _, g := range groups {
+		for _, v := range g.Variants {
+			for _, f := range v.Fields {
+				f.Path = "<" + v.Name + ">" + f.Path
+				out = append(out, f)
+			}
+		}
+	}
+	return out
+}
+
+// variantExample 生成变体的示例值，并在对象示例中填入区分字段取值。
+func variantExample(j *gjson.Json, g VariantGroup, v VariantInfo, view schemaView, seen map[string]bool) interface{} {
+	ex := exampleValueSeen(j, v.schema, view, seen)
+	if m, ok := ex.(map[string]interface{}); ok && g.Discriminator != "" && v.DiscriminatorValue != "" {
+		m[g.Discriminator] = v.DiscriminatorValue
+	}
+	return ex
+}
+
+// variantGroupTitle 变体分组的说明文本：组合方式、出现位置与区分字段。
+func variantGroupTitle(g VariantGroup, loc Locale, code func(string) string) string {
+	title := loc.T("variants_" + g.Kind)
+	at := g.Path
+	if at == "" {
+		at = loc.T("variant_root")
+	}
+	title += " · " + loc.T("variant_at") + " " + code(at)
+	if g.Discriminator != "" {
+		title += " · " + loc.T("discriminator") + " " + code(g.Discriminator)
+	}
+	return title
+}
+
+// variantTitle 单个变体的标题：名称与区分字段取值。
+func variantTitle(g VariantGroup, v VariantInfo, code func(string) string) string {
+	t := v.Name
+	if g.Discriminator != "" && v.DiscriminatorValue != "" {
+		t += " (" + code(g.Discriminator+" = "+v.DiscriminatorValue) + ")"
+	}
+	return t
+}
+
+// renderVariantGroupsHTML 渲染变体分组：每个变体一张字段表与示例；request 为 true 时使用请求参数表样式。
+func renderVariantGroupsHTML(groups []VariantGroup, request bool, loc Locale) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	code := func(s string) string { return "<code>" + htmlEscape(s) + "</code>" }
+	var b strings.Builder
+	for _, g := range groups {
+		b.WriteString("<div class=\"variants\"><p class=\"variant-group\">" + variantGroupTitle(g, loc, code) + "</p>")
+		for _, v := range g.Variants {
+			ev := v
+			ev.Name = htmlEscape(v.Name)
+			b.WriteString("<h4 class=\"variant\">" + variantTitle(g, ev, code) + "</h4>")
+			if request {
+				b.WriteString(renderRequestFieldTableHTML(v.Fields, loc))
+			} else {
+				b.WriteString(renderResponseFieldTableHTML(v.Fields, loc))
+			}
+			if v.Example != "" {
+				b.WriteString("<pre><code>" + htmlEscape(v.Example) + "</code></pre>")
+			}
+		}
+		b.WriteString("</div>")
+	}
+	return b.String()
+}
+
+// renderVariantGroupsMarkdown 以 Markdown 渲染变体分组，分组标题为 level 级。
+func renderVariantGroupsMarkdown(groups []VariantGroup, request bool, loc Locale, level int) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	code := func(s string) string { return "`" + s + "`" }
+	var b strings.Builder
+	for _, g := range groups {
+		b.WriteString(mdHeading(level, variantGroupTitle(g, loc, code)))
+		for _, v := range g.Variants {
+			b.WriteString("**" + variantTitle(g, v, code) + "**\n\n")
+			if request {
+				b.WriteString(renderRequestFieldTableMarkdown(v.Fields, loc) + "\n")
+			} else {
+				b.WriteString(renderResponseFieldTableMarkdown(v.Fields, loc) + "\n")
+			}
+			if v.Example != "" {
+				b.WriteString("```json\n" + v.Example + "\n```\n\n")
+			}
+		}
+	}
+	return b.String()
+}
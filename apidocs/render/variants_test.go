@@ -0,0 +1,84 @@
+package render
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+const variantSpec = `{"paths":{"/pets":{"post":{
+	"requestBody":{"content":{"application/json":{"schema":{"anyOf":[{"type":"string"},{"type":"integer","title":"Code"},{"type":"object","properties":{"q":{"type":"string"}}}]}}}},
+	"responses":{"200":{"content":{"application/json":{"schema":{"type":"object","properties":{
+		"code":{"type":"integer"},
+		"data":{"oneOf":[{"$ref":"#/components/schemas/Cat"},{"$ref":"#/components/schemas/Dog"},{"$ref":"#/components/schemas/Bird"}],
+			"discriminator":{"propertyName":"kind","mapping":{"kitty":"#/components/schemas/Cat","a-cat":"#/components/schemas/Cat"}}}}}}}}}}}},
+	"components":{"schemas":{
+		"Cat":{"type":"object","properties":{"kind":{"type":"string"},"lives":{"type":"integer","default":9}}},
+		"Dog":{"type":"object","properties":{"kind":{"type":"string","enum":["doggo","pup"]},"bark":{"type":"boolean"}}},
+		"Bird":{"type":"object","properties":{"kind":{"type":"string"},"wings":{"type":"integer"}}}}}}`
+
+func variantEndpoint(t *testing.T) EndpointData {
+	t.Helper()
+	j := gjson.New(variantSpec)
+	pj := j.GetJsonMap("paths")["/pets"]
+	return buildEndpoint(j, "/pets", "post", pj, pj.GetJson("post"), RenderConfig{})
+}
+
+func TestDiscriminatorValues(t *testing.T) {
+	ep := variantEndpoint(t)
+	if len(ep.ResponseVariants) != 1 {
+		t.Fatalf("response variant groups = %d, want 1", len(ep.ResponseVariants))
+	}
+	g := ep.ResponseVariants[0]
+	if g.Path != "data" || g.Kind != "oneOf" || g.Discriminator != "kind" {
+		t.Errorf("group = %s %s %s", g.Path, g.Kind, g.Discriminator)
+	}
+	// Cat: mapping 反查（多个键指向同一组件时取排序后的第一个）；Dog: 区分字段的首个枚举值；Bird: 组件名
+	want := map[string]string{"Cat": "a-cat", "Dog": "doggo", "Bird": "Bird"}
+	for _, v := range g.Variants {
+		if v.DiscriminatorValue != want[v.Name] {
+			t.Errorf("%s discriminator value = %q, want %q", v.Name, v.DiscriminatorValue, want[v.Name])
+		}
+		var ex map[string]interface{}
+		if err := json.Unmarshal([]byte(v.Example), &ex); err != nil || ex["kind"] != want[v.Name] {
+			t.Errorf("%s example = %s, want kind %q", v.Name, v.Example, want[v.Name])
+		}
+		if len(v.Fields) == 0 || !strings.HasPrefix(v.Fields[0].Path, "data.") {
+			t.Errorf("%s fields = %+v, want paths under data", v.Name, v.Fields)
+		}
+	}
+	var rows []string
+	for _, f := range ep.ResponseFields {
+		rows = append(rows, f.Path+":"+f.Type)
+	}
+	if got := strings.Join(rows, ","); got != "data:oneOf,code:integer" {
+		t.Errorf("response rows = %s", got)
+	}
+}
+
+func TestAnyOfVariantNames(t *testing.T) {
+	ep := variantEndpoint(t)
+	if len(ep.RequestVariants) != 1 {
+		t.Fatalf("request variant groups = %d, want 1", len(ep.RequestVariants))
+	}
+	g := ep.RequestVariants[0]
+	var names []string
+	for _, v := range g.Variants {
+		names = append(names, v.Name)
+		if v.DiscriminatorValue != "" {
+			t.Errorf("%s has a discriminator value without a discriminator", v.Name)
+		}
+	}
+	if g.Kind != "anyOf" || strings.Join(names, ",") != "string,Code,#3" {
+		t.Errorf("group = %s %v", g.Kind, names)
+	}
+	if f := g.Variants[0].Fields; len(f) != 1 || f[0].Path != "(value)" || f[0].Type != "string" {
+		t.Errorf("primitive variant fields = %+v", f)
+	}
+	md := renderVariantGroupsMarkdown(ep.ResponseVariants, false, ep.Locale, 6)
+	if !strings.Contains(md, "**Cat (`kind = a-cat`)**") {
+		t.Errorf("markdown variant title missing:\n%s", md)
+	}
+}
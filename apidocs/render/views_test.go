@@ -0,0 +1,78 @@
+package render
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// viewSpec 请求与返回共用 User：id 为 readOnly，password 为 writeOnly（经 $ref 组件标记），profile.createdAt 为嵌套 readOnly，
+// allOf 中的 token 为 writeOnly。
+const viewSpec = `{"paths":{"/users":{"post":{
+	"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}},
+	"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}}}}}},
+	"components":{"schemas":{
+		"Secret":{"type":"string","writeOnly":true,"default":"pw"},
+		"User":{"allOf":[{"type":"object","properties":{"token":{"type":"string","writeOnly":true}}}],"type":"object","required":["id","name"],"properties":{
+			"id":{"type":"integer","readOnly":true,"default":1},
+			"name":{"type":"string","default":"tom"},
+			"password":{"$ref":"#/components/schemas/Secret"},
+			"profile":{"type":"object","properties":{"createdAt":{"type":"string","readOnly":true},"bio":{"type":"string","default":"hi"}}}}}}}}`
+
+func TestReadWriteOnlyViews(t *testing.T) {
+	j := gjson.New(viewSpec)
+	pj := j.GetJsonMap("paths")["/users"]
+	ep := buildEndpoint(j, "/users", "post", pj, pj.GetJson("post"), RenderConfig{})
+	paths := func(fs []FieldInfo) string {
+		var res []string
+		for _, f := range fs {
+			res = append(res, f.Path)
+		}
+		return strings.Join(res, ",")
+	}
+	if got := paths(ep.RequestFields); got != "name,password,profile,profile.bio,token" {
+		t.Errorf("request fields = %s", got)
+	}
+	if got := paths(ep.ResponseFields); got != "id,name,profile,profile.bio,profile.createdAt" {
+		t.Errorf("response fields = %s", got)
+	}
+	examples := []struct {
+		name, text string
+		want       map[string]interface{}
+	}{
+		{"request", ep.RequestExample, map[string]interface{}{"name": "tom", "password": "pw", "token": "string", "profile": map[string]interface{}{"bio": "hi"}}},
+		{"response", ep.ResponseExample, map[string]interface{}{"id": float64(1), "name": "tom", "profile": map[string]interface{}{"bio": "hi", "createdAt": "string"}}},
+	}
+	for _, e := range examples {
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(e.text), &got); err != nil || !reflect.DeepEqual(got, e.want) {
+			t.Errorf("%s example = %s, want %v", e.name, e.text, e.want)
+		}
+	}
+}
+
+func TestSchemaViewHides(t *testing.T) {
+	j := gjson.New(viewSpec)
+	ro := gjson.New(`{"readOnly":true}`)
+	wo := gjson.New(`{"$ref":"#/components/schemas/Secret"}`)
+	dep := gjson.New(`{"deprecated":true}`)
+	cases := []struct {
+		view       schemaView
+		ro, wo, dp bool
+	}{
+		{viewAll, false, false, false},
+		{viewRequest, true, false, false},
+		{viewResponse, false, true, false},
+		{viewResponse | viewNoDeprecated, false, true, true},
+		{RenderConfig{HideDeprecated: true}.fieldView(viewRequest), true, false, true},
+	}
+	for _, c := range cases {
+		if c.view.hides(j, ro) != c.ro || c.view.hides(j, wo) != c.wo || c.view.hides(j, dep) != c.dp {
+			t.Errorf("view %b hides readOnly/writeOnly/deprecated = %v/%v/%v, want %v/%v/%v", c.view,
+				c.view.hides(j, ro), c.view.hides(j, wo), c.view.hides(j, dep), c.ro, c.wo, c.dp)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package apidocs
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -38,115 +39,147 @@ func RegisterWithConfig(s *ghttp.Server, defaultContent string, cfg config.Confi
 			srv = vv
 		}
 	}
+	srv.watchCustomize(c)
 	// 文档页面：GET /docs
 	s.BindHandler("GET:"+c.RouteDocs, func(r *ghttp.Request) {
-		spec := srv.spec
-		raw := srv.raw
-		// 转发查询参数到远程源：当配置了 Domain/Port/Path 时生效
-		// 请求地址来源：Domain+Port+Path 组合；请求参数来源：r.GetMap()（排除 src）
-		if c.Domain != "" && c.Path != "" {
-			params := r.GetMap()
-			base := "http://" + c.Domain
-			if c.Port > 0 {
-				base = base + fmt.Sprintf(":%d", c.Port)
-			}
-			p := c.Path
-			if !strings.HasPrefix(p, "/") {
-				p = "/" + p
-			}
-			var b strings.Builder
-			first := true
-			for k, v := range params {
-				if k == "src" {
-					continue
-				}
-				if first {
-					first = false
-				} else {
-					b.WriteByte('&')
-				}
-				b.WriteString(url.QueryEscape(k))
-				b.WriteByte('=')
-				b.WriteString(url.QueryEscape(fmt.Sprintf("%v", v)))
-			}
-			src := base + p
-			if b.Len() > 0 {
-				src = src + "?" + b.String()
-			}
-			if j2, content2, e := source.LoadSpecFromSource(src); e == nil && j2 != nil {
-				spec = j2
-				raw = content2
-			}
-		}
-		// src 参数支持本地/远程地址，且对 Windows 路径和片段（#Lx-y）做归一化
-		// 显式 src 优先于 Domain/Port/Path 转发逻辑
-		if src := r.Get("src").String(); src != "" {
-			if j2, content, err := source.LoadSpecFromSource(src); err == nil && j2 != nil {
-				spec = j2
-				raw = content
-			}
-		}
-		srv.spec = spec
-		srv.raw = raw
+		spec, raw := srv.loadSpec(r, c)
+		spec = render.FilterAudience(spec, requestAudience(r, c))
 		r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
 		r.Response.Header().Set("X-OpenAPI-Source", r.Get("src").String())
 		r.Response.Header().Set("X-Paths-Count", fmt.Sprintf("%d", len(spec.GetJsonMap("paths"))))
-		r.Response.Write(render.GenerateHTMLWithConfig(spec, raw, c.ToRenderConfig()))
+		r.Response.Write(render.GenerateHTMLWithConfig(spec, raw, srv.renderConfig(r, c)))
 	})
 	// Markdown 导出：GET /docs.md
 	s.BindHandler("GET:"+c.RouteMarkdown, func(r *ghttp.Request) {
-		spec := srv.spec
-		raw := srv.raw
-		// 同文档页面逻辑：优先根据配置转发查询参数以拉取规范
-		if c.Domain != "" && c.Path != "" {
-			params := r.GetMap()
-			base := "http://" + c.Domain
-			if c.Port > 0 {
-				base = base + fmt.Sprintf(":%d", c.Port)
-			}
-			p := c.Path
-			if !strings.HasPrefix(p, "/") {
-				p = "/" + p
-			}
-			var b strings.Builder
-			first := true
-			for k, v := range params {
-				if k == "src" {
-					continue
-				}
-				if first {
-					first = false
-				} else {
-					b.WriteByte('&')
-				}
-				b.WriteString(url.QueryEscape(k))
-				b.WriteByte('=')
-				b.WriteString(url.QueryEscape(fmt.Sprintf("%v", v)))
-			}
-			src := base + p
-			if b.Len() > 0 {
-				src = src + "?" + b.String()
-			}
-			if j2, content2, e := source.LoadSpecFromSource(src); e == nil && j2 != nil {
-				spec = j2
-				raw = content2
-			}
-		}
-		// 显式 src 优先于转发逻辑，用于直接指定源
-		if src := r.Get("src").String(); src != "" {
-			if j2, content, err := source.LoadSpecFromSource(src); err == nil && j2 != nil {
-				spec = j2
-				raw = content
-			}
-		}
-		srv.spec = spec
-		srv.raw = raw
+		spec, raw := srv.loadSpec(r, c)
+		spec = render.FilterAudience(spec, requestAudience(r, c))
 		r.Response.Header().Set("X-OpenAPI-Source", r.Get("src").String())
 		r.Response.Header().Set("X-Paths-Count", fmt.Sprintf("%d", len(spec.GetJsonMap("paths"))))
-		md := render.GenerateMarkdownWithConfig(spec, raw, c.ToRenderConfig())
+		md := render.GenerateMarkdownWithConfig(spec, raw, srv.renderConfig(r, c))
 		r.Response.Header().Set("Content-Type", "text/markdown; charset=utf-8")
 		r.Response.Header().Set("Content-Disposition", "attachment; filename=api-docs.md")
 		r.Response.Write(md)
 	})
+	// 变更报告：GET /docs/diff?base=<旧规范>&target=<新规范>&format=html|md|json
+	s.BindHandler("GET:"+c.RouteDiff, func(r *ghttp.Request) {
+		serveDiff(r, srv, c)
+	})
+	// 文档质量检查：GET /docs/lint?format=html|json
+	s.BindHandler("GET:"+c.RouteLint, func(r *ghttp.Request) {
+		spec, raw := srv.loadSpec(r, c)
+		spec = render.FilterAudience(spec, requestAudience(r, c))
+		rc := srv.renderConfig(r, c)
+		rep := render.LintSpecWithConfig(spec, raw, c.ToLintConfig(), rc)
+		r.Response.Header().Set("X-Lint-Errors", fmt.Sprintf("%d", rep.Errors))
+		if r.Get("format").String() == "json" {
+			bs, _ := json.MarshalIndent(rep, "", "  ")
+			r.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
+			r.Response.Write(bs)
+			return
+		}
+		r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+		r.Response.Write(render.GenerateLintHTML(rep, c.RouteDocs, rc))
+	})
+	// 模拟服务：ALL /mock/*（需开启 Mock）
+	if c.Mock {
+		registerMock(s, srv, c)
+	}
 	return srv
 }
+
+// loadSpec 按请求确定本次渲染使用的规范；不修改 Server 的共享状态。
+// 说明：
+// - 当配置了 Domain/Port/Path 时，将请求查询参数（排除 src）转发到远程源拉取规范；
+// - 显式 src 优先于转发逻辑，支持本地/远程地址，且对 Windows 路径和片段（#Lx-y）做归一化；
+// - 拉取失败时回退到默认规范。
+func (srv *Server) loadSpec(r *ghttp.Request, c config.Config) (*gjson.Json, string) {
+	spec := srv.spec
+	raw := srv.raw
+	// 请求地址来源：Domain+Port+Path 组合；请求参数来源：r.GetMap()（排除 src）
+	if c.Domain != "" && c.Path != "" {
+		if j2, content2, e := source.LoadSpecFromSource(remoteSource(c, r.GetMap())); e == nil && j2 != nil {
+			spec = j2
+			raw = content2
+		}
+	}
+	if src := r.Get("src").String(); src != "" {
+		if j2, content, err := source.LoadSpecFromSource(src); err == nil && j2 != nil {
+			spec = j2
+			raw = content
+		}
+	}
+	return spec, raw
+}
+
+// defaultSpec 返回默认规范（注册时提供的文本）；为空且配置了 Domain/Port/Path 时拉取不带查询参数的远程源。
+func (srv *Server) defaultSpec(c config.Config) (*gjson.Json, string) {
+	if srv.spec == nil && c.Domain != "" && c.Path != "" {
+		if j2, content, e := source.LoadSpecFromSource(remoteSource(c, nil)); e == nil && j2 != nil {
+			return j2, content
+		}
+	}
+	return srv.spec, srv.raw
+}
+
+// renderConfig 返回单次请求的渲染配置：定制规则取当前生效的规则（含 CustomizeFile 热更新结果）；
+// 语言按 ?lang=、Accept-Language、Config.Lang 的顺序确定；?deprecated=hide|show 覆盖 Config.HideDeprecated。
+func (srv *Server) renderConfig(r *ghttp.Request, c config.Config) render.RenderConfig {
+	c.Customize = srv.customizeRules(c)
+	rc := c.ToRenderConfig()
+	rc.Lang = render.NegotiateLang(r.Get("lang").String(), r.Header.Get("Accept-Language"), rc.Lang, rc.Locales)
+	switch r.Get("deprecated").String() {
+	case "hide":
+		rc.HideDeprecated = true
+	case "show":
+		rc.HideDeprecated = false
+	}
+	return rc
+}
+
+// requestAudience 返回单次请求的受众：?audience= 命中 Config.Audiences 时使用该值，否则为 Config.Audience。
+// 返回空串表示不按受众裁剪。
+func requestAudience(r *ghttp.Request, c config.Config) string {
+	if a := strings.TrimSpace(r.Get("audience").String()); a != "" {
+		for _, allowed := range c.Audiences {
+			if strings.EqualFold(a, allowed) {
+				return allowed
+			}
+		}
+	}
+	return c.Audience
+}
+
+// reservedQuery 文档路由自身使用的查询参数，不转发到远程源。
+var reservedQuery = map[string]struct{}{"src": {}, "format": {}, "lang": {}, "deprecated": {}, "audience": {}}
+
+// remoteSource 由 Domain+Port+Path 与查询参数（排除 src、format 等保留参数）拼接远程 OpenAPI 源地址。
+func remoteSource(c config.Config, params map[string]interface{}) string {
+	base := "http://" + c.Domain
+	if c.Port > 0 {
+		base = base + fmt.Sprintf(":%d", c.Port)
+	}
+	p := c.Path
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	var b strings.Builder
+	first := true
+	for k, v := range params {
+		if _, ok := reservedQuery[k]; ok {
+			continue
+		}
+		if first {
+			first = false
+		} else {
+			b.WriteByte('&')
+		}
+		b.WriteString(url.QueryEscape(k))
+		b.WriteByte('=')
+		b.WriteString(url.QueryEscape(fmt.Sprintf("%v", v)))
+	}
+	src := base + p
+	if b.Len() > 0 {
+		src = src + "?" + b.String()
+	}
+	return src
+}
@@ -0,0 +1,34 @@
+package apidocs
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/megatrZlp/go-apidocs/apidocs/config"
+)
+
+func TestRequestAudience(t *testing.T) {
+	c := config.Config{Audience: "partner", Audiences: []string{"partner", "Internal"}}
+	cases := []struct {
+		query string
+		cfg   config.Config
+		want  string
+	}{
+		{"", c, "partner"},
+		// 命中允许列表（忽略大小写）时使用列表中的写法
+		{"?audience=internal", c, "Internal"},
+		{"?audience=%20partner%20", c, "partner"},
+		// 未列出的受众被忽略，回退到路由默认受众
+		{"?audience=admin", c, "partner"},
+		// 未配置 Audiences 时不允许切换
+		{"?audience=internal", config.Config{Audience: "partner"}, "partner"},
+		{"?audience=internal", config.Config{}, ""},
+	}
+	for _, tc := range cases {
+		r := &ghttp.Request{Request: httptest.NewRequest("GET", "/docs"+tc.query, nil)}
+		if got := requestAudience(r, tc.cfg); got != tc.want {
+			t.Errorf("requestAudience(%q, %+v) = %q, want %q", tc.query, tc.cfg.Audiences, got, tc.want)
+		}
+	}
+}
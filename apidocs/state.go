@@ -1,11 +1,30 @@
 package apidocs
 
-import "github.com/gogf/gf/v2/encoding/gjson"
+import (
+	"sync"
+
+	"github.com/megatrZlp/go-apidocs/apidocs/config"
+	"github.com/megatrZlp/go-apidocs/apidocs/render"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
 
 // Server 持有默认的 OpenAPI 解析对象与其原始文本，用于路由处理时回退。
 // - spec: 解析后的 OpenAPI 结构
 // - raw: 原始 JSON 文本（保持 paths 原始顺序）
+// - customize: 合并 CustomizeFile 后生效的定制规则（未配置文件或首次加载失败时为 nil，使用 Config.Customize）
+// - mock: 模拟服务使用的规范及其路由表（默认规范或不带查询参数的远程源，首次成功加载后固定）
+// spec 与 raw 在注册后只读；customize 与 mock 由 mu 保护。
 type Server struct {
-	spec *gjson.Json
-	raw  string
+	spec      *gjson.Json
+	raw       string
+	mu        sync.RWMutex
+	customize map[string]config.CustomizeReqAndRes
+	mock      *mockState
+}
+
+// mockState 模拟服务的规范与编译后的路由表。
+type mockState struct {
+	spec   *gjson.Json
+	routes *render.Mock
 }
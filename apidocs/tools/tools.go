@@ -6,7 +6,7 @@ import (
 	"github.com/gogf/gf/v2/encoding/gjson"
 )
 
-// GetRefJSON 解析 components 下的 $ref（支持 schemas/parameters 两类引用）。
+// GetRefJSON 解析 components 下的 $ref（支持 schemas/parameters/responses/examples 等各类引用）。
 func GetRefJSON(j *gjson.Json, ref string) *gjson.Json {
 	if strings.HasPrefix(ref, "#/components/schemas/") {
 		if j == nil {
@@ -14,11 +14,16 @@ func GetRefJSON(j *gjson.Json, ref string) *gjson.Json {
 		}
 		return GetSchema(j, ComponentNameFromRef(ref))
 	}
-	if strings.HasPrefix(ref, "#/components/parameters/") {
+	if strings.HasPrefix(ref, "#/components/") {
 		if j == nil {
 			return nil
 		}
-		pm := j.GetJsonMap("components.parameters")
+		rest := strings.TrimPrefix(ref, "#/components/")
+		i := strings.Index(rest, "/")
+		if i <= 0 {
+			return nil
+		}
+		pm := j.GetJsonMap("components." + rest[:i])
 		return pm[ComponentNameFromRef(ref)]
 	}
 	return nil
@@ -117,24 +122,6 @@ func Slugify(s string) string {
 	return s
 }
 
-// SplitTagParts 将 tags[0] 按“主/次”分组，例如 “用户模块/用户管理”。
-func SplitTagParts(tags []string) (string, string) {
-	if len(tags) == 0 {
-		return "未分组", "默认"
-	}
-	s := tags[0]
-	i := strings.Index(s, "/")
-	if i < 0 {
-		return s, "默认"
-	}
-	pre := s[:i]
-	suf := s[i+1:]
-	if suf == "" {
-		suf = "默认"
-	}
-	return pre, suf
-}
-
 // ComponentNameFromRef 从 $ref 中提取末尾组件名。
 func ComponentNameFromRef(ref string) string {
 	i := strings.LastIndex(ref, "/")
@@ -171,3 +158,17 @@ func StripComponentTypeDecorations(s string) string {
 	}
 	return s
 }
+
+// SplitGroupPath 按分隔符将分组字符串拆分为层级（分隔符为空时取 "/"），跳过空段，如 "用户.管理"（分隔符 "."）→ [用户 管理]。
+func SplitGroupPath(s, delim string) []string {
+	if delim == "" {
+		delim = "/"
+	}
+	var res []string
+	for _, seg := range strings.Split(s, delim) {
+		if strings.TrimSpace(seg) != "" {
+			res = append(res, seg)
+		}
+	}
+	return res
+}
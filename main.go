@@ -13,6 +13,11 @@ func main() {
 		Domain: "127.0.0.1",
 		Port:   10014,
 		Path:   "/server/swagger/api.json",
+		Customize: map[string]config.CustomizeReqAndRes{
+			"/v1/record/record/**": {
+				Headers: map[string]string{"accessToken": "string#required#登录获取的accessToken"},
+			},
+		},
 	})
 	s.SetPort(8000)
 	s.Run()